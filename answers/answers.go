@@ -0,0 +1,114 @@
+// Package answers stores the known answers of each day so that computed answers can be verified.
+package answers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// DefaultPath is the path of the answers file, relative to the root of the repository
+const DefaultPath = "answers.json"
+
+// Answers holds the answers of each day, keyed by day number
+type Answers map[int]Day
+
+// Day holds the answers of a day
+type Day struct {
+	// Input holds the hash of the input the answers belong to, the answers match any input if it's empty
+	Input string `json:"input,omitempty"`
+	// Parts holds the answer of each part, keyed by part number
+	Parts map[int]string `json:"parts"`
+}
+
+// Verdict is the result of checking an answer
+type Verdict int
+
+const (
+	// Missing means that there is no known answer to compare with
+	Missing Verdict = iota
+	// Pass means that the answer is the known one
+	Pass
+	// Fail means that the answer is not the known one
+	Fail
+)
+
+// String returns the name of the verdict
+func (v Verdict) String() string {
+	switch v {
+	case Missing:
+		return "MISSING"
+	case Pass:
+		return "PASS"
+	case Fail:
+		return "FAIL"
+	}
+	return fmt.Sprintf("Verdict(%d)", int(v))
+}
+
+// Load reads the answers from the given file
+func Load(path string) (Answers, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %s: %w", path, err)
+	}
+
+	var answers Answers
+	if err := json.Unmarshal(bytes, &answers); err != nil {
+		return nil, fmt.Errorf("could not parse answers from %s: %w", path, err)
+	}
+	if answers == nil {
+		answers = Answers{}
+	}
+	return answers, nil
+}
+
+// Save writes the answers to the given file
+func (a Answers) Save(path string) error {
+	bytes, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode answers: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(bytes, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get returns the known answer of a part and whether it was found
+func (a Answers) Get(day, part int) (string, bool) {
+	answer, ok := a[day].Parts[part]
+	return answer, ok
+}
+
+// Set stores the answer of a part for the input with the given hash.
+// The answers of the other parts are dropped if they belong to another input.
+func (a Answers) Set(day, part int, input, answer string) {
+	d, ok := a[day]
+	if !ok || d.Parts == nil || (d.Input != "" && d.Input != input) {
+		d = Day{Parts: make(map[int]string)}
+	}
+	d.Input = input
+	d.Parts[part] = answer
+	a[day] = d
+}
+
+// Check compares the answer of a part with the known one.
+// There is no known answer if it belongs to another input than the one with the given hash.
+func (a Answers) Check(day, part int, input, answer string) Verdict {
+	expected, ok := a.Get(day, part)
+	if !ok || !a.matches(day, input) {
+		return Missing
+	}
+	if answer != expected {
+		return Fail
+	}
+	return Pass
+}
+
+// matches returns true if the answers of a day belong to the input with the given hash
+func (a Answers) matches(day int, input string) bool {
+	known := a[day].Input
+	return known == "" || input == "" || known == input
+}
@@ -0,0 +1,106 @@
+package answers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnswersShould(t *testing.T) {
+	t.Run("check answers", func(t *testing.T) {
+		a := Answers{}
+		a.Set(1, 1, "abc", "24000")
+
+		assert.Equal(t, Pass, a.Check(1, 1, "abc", "24000"))
+		assert.Equal(t, Fail, a.Check(1, 1, "abc", "45000"))
+		assert.Equal(t, Missing, a.Check(1, 2, "abc", "45000"))
+		assert.Equal(t, Missing, a.Check(2, 1, "abc", "15"))
+	})
+
+	t.Run("not check answers of another input", func(t *testing.T) {
+		a := Answers{}
+		a.Set(1, 1, "abc", "24000")
+
+		assert.Equal(t, Missing, a.Check(1, 1, "def", "45000"))
+	})
+
+	t.Run("check answers without input against any input", func(t *testing.T) {
+		a := Answers{1: {Parts: map[int]string{1: "24000"}}}
+
+		assert.Equal(t, Pass, a.Check(1, 1, "abc", "24000"))
+		assert.Equal(t, Fail, a.Check(1, 1, "def", "45000"))
+	})
+
+	t.Run("drop answers of another input", func(t *testing.T) {
+		a := Answers{}
+		a.Set(1, 1, "abc", "24000")
+		a.Set(1, 2, "abc", "45000")
+		a.Set(1, 1, "def", "11000")
+
+		assert.Equal(t, Answers{1: {Input: "def", Parts: map[int]string{1: "11000"}}}, a)
+	})
+
+	t.Run("keep answers without input when setting one", func(t *testing.T) {
+		a := Answers{1: {Parts: map[int]string{1: "24000"}}}
+		a.Set(1, 2, "abc", "45000")
+
+		assert.Equal(t, Answers{1: {Input: "abc", Parts: map[int]string{1: "24000", 2: "45000"}}}, a)
+	})
+
+	t.Run("save and load answers", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.json")
+		a := Answers{}
+		a.Set(1, 1, "abc", "24000")
+		a.Set(1, 2, "abc", "45000")
+		a.Set(10, 2, "", "\n##..\n#..#\n")
+
+		require.NoError(t, a.Save(path))
+		loaded, err := Load(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, a, loaded)
+
+		bytes, err := os.ReadFile(path)
+		require.NoError(t, err)
+		expected := `{
+  "1": {
+    "input": "abc",
+    "parts": {
+      "1": "24000",
+      "2": "45000"
+    }
+  },
+  "10": {
+    "parts": {
+      "2": "\n##..\n#..#\n"
+    }
+  }
+}
+`
+		assert.Equal(t, expected, string(bytes))
+	})
+
+	t.Run("fail to load a missing file", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "answers.json"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("fail to load an invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"1": []}`), 0644))
+
+		_, err := Load(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestVerdictShould(t *testing.T) {
+	t.Run("have a name", func(t *testing.T) {
+		assert.Equal(t, "MISSING", Missing.String())
+		assert.Equal(t, "PASS", Pass.String())
+		assert.Equal(t, "FAIL", Fail.String())
+	})
+}
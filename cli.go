@@ -0,0 +1,291 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OctaviPascual/AdventOfCode2022/answers"
+	"github.com/OctaviPascual/AdventOfCode2022/runner"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/workspace"
+)
+
+const (
+	firstDay = 1
+	lastDay  = 25
+)
+
+// runOptions holds the options given to the commands that run days
+type runOptions struct {
+	// days holds the days to run, all of them are run if it's empty
+	days util.Set[int]
+	// skip holds the days that must not be run
+	skip util.Set[int]
+	// part holds the part to run, both are run if it's 0
+	part            int
+	includeDisabled bool
+	// workers holds how many parts can be solved at the same time
+	workers int
+	// timeout holds the maximum time to solve each part, there is no limit if it's 0
+	timeout time.Duration
+	// checked makes the days that support it fail when their arithmetic overflows instead of wrapping around
+	checked bool
+	// statsFormat holds the format of the stats report, no report is written if it's empty
+	statsFormat string
+	// statsFile holds the file where the stats report is written, it's written to stdout if it's empty
+	statsFile string
+	// answersPath holds the path of the answers file used to verify and record answers
+	answersPath string
+	// root holds the directory that the input paths of the days are relative to
+	root string
+	// output holds the format of the answers
+	output string
+	// cache holds the directory of the input cache, inputs are not checked if it's empty
+	cache string
+	// refreshCache replaces the cached inputs with the current ones
+	refreshCache bool
+	// explain prints how the days that can explain their answers reached them
+	explain bool
+	// input holds the path of the input to use instead of the one of the day, it's read from stdin if it's "-"
+	input string
+}
+
+func parseRunOptions(command string, args []string) (runOptions, error) {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	day := fs.Int("day", 0, "run only the given `day`")
+	days := fs.String("days", "", "run only the given `days`, such as 1-10,12")
+	skip := fs.String("skip", "", "do not run the given `days`, such as 19,24")
+	part := fs.Int("part", 0, "run only the given `part` (1 or 2) instead of both")
+	includeDisabled := fs.Bool("include-disabled", false, "also run the days that are disabled")
+	workers := fs.Int("workers", 1, "solve up to `n` parts concurrently")
+	timeout := fs.Duration("timeout", 0, "stop solving a part after the given `duration`, such as 30s")
+	checked := fs.Bool("checked", false, "fail days 11, 20 and 21 when their arithmetic overflows instead of wrapping around")
+	statsFormat := fs.String("stats", "", "report time and memory used by each day in the given `format` ("+strings.Join(runner.StatsFormats, ", ")+"), memory is only accurate with a single worker")
+	statsFile := fs.String("stats-file", "", "write the stats report to the given `file` instead of stdout")
+	output := fs.String("output", "text", "write the answers in the given `format` ("+strings.Join(runner.OutputFormats, ", ")+")")
+	root := fs.String("root", defaultRoot(), "`directory` of the repository, the input of each day is read from it (default $"+rootEnv+" or the working directory)")
+	cache := fs.String("cache", defaultCache(), "`directory` where a copy of each input is kept to detect modified or truncated inputs, disabled if empty (default $"+cacheEnv+")")
+	refreshCache := fs.Bool("refresh-cache", false, "replace the cached inputs with the current ones, to accept inputs that were replaced on purpose")
+	explain := fs.Bool("explain", false, "print how the answers were reached for the days that can explain them, such as the valves opened in day 16")
+	input := fs.String("input", "", "read the input of the selected day from the given `path`, or from stdin if it's "+stdinPath)
+	answersPath := ""
+	if command != "run" {
+		fs.StringVar(&answersPath, "answers", "", "`path` of the answers file (default "+answers.DefaultPath+" in the root directory)")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return runOptions{}, err
+	}
+	if fs.NArg() > 0 {
+		return runOptions{}, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	opts := runOptions{
+		days:            util.NewSet[int](),
+		part:            *part,
+		includeDisabled: *includeDisabled,
+		workers:         *workers,
+		timeout:         *timeout,
+		checked:         *checked,
+		statsFormat:     *statsFormat,
+		statsFile:       *statsFile,
+		answersPath:     answersPath,
+		output:          *output,
+		root:            *root,
+		cache:           *cache,
+		refreshCache:    *refreshCache,
+		explain:         *explain,
+		input:           *input,
+	}
+	if opts.answersPath == "" {
+		opts.answersPath = filepath.Join(opts.root, answers.DefaultPath)
+	}
+
+	if *day != 0 {
+		if err := validateDay(*day); err != nil {
+			return runOptions{}, fmt.Errorf("invalid day: %w", err)
+		}
+		opts.days.Add(*day)
+	}
+
+	selected, err := parseDays(*days)
+	if err != nil {
+		return runOptions{}, fmt.Errorf("invalid days: %w", err)
+	}
+	opts.days.Add(selected.Members()...)
+
+	opts.skip, err = parseDays(*skip)
+	if err != nil {
+		return runOptions{}, fmt.Errorf("invalid skipped days: %w", err)
+	}
+
+	if opts.part != 0 && opts.part != 1 && opts.part != 2 {
+		return runOptions{}, fmt.Errorf("invalid part %d: must be 1 or 2", opts.part)
+	}
+
+	if opts.workers < 1 {
+		return runOptions{}, fmt.Errorf("invalid workers %d: must be at least 1", opts.workers)
+	}
+
+	if opts.input != "" && len(opts.days) != 1 {
+		return runOptions{}, fmt.Errorf("an input can only be given when a single day is selected")
+	}
+
+	if opts.timeout < 0 {
+		return runOptions{}, fmt.Errorf("invalid timeout %s: must not be negative", opts.timeout)
+	}
+
+	if !slices.Contains(runner.OutputFormats, opts.output) {
+		return runOptions{}, fmt.Errorf("invalid output format %q: must be one of %s", opts.output, strings.Join(runner.OutputFormats, ", "))
+	}
+
+	if opts.statsFormat != "" && !slices.Contains(runner.StatsFormats, opts.statsFormat) {
+		return runOptions{}, fmt.Errorf("invalid stats format %q: must be one of %s", opts.statsFormat, strings.Join(runner.StatsFormats, ", "))
+	}
+
+	return opts, nil
+}
+
+// sessionEnv is the environment variable holding the session cookie used to download the puzzle inputs
+const sessionEnv = "AOC_SESSION_COOKIE"
+
+// newDayOptions holds the options given to the new-day command
+type newDayOptions struct {
+	day workspace.Day
+	// root holds the directory of the repository where the day is created
+	root string
+	// fetcher returns the input of the day
+	fetcher workspace.Fetcher
+}
+
+// parseNewDayOptions parses the arguments of the new-day command, the day can be given before or after the flags
+func parseNewDayOptions(args []string) (newDayOptions, error) {
+	fs := flag.NewFlagSet("new-day", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go run . new-day [options] DAY\n")
+		fs.PrintDefaults()
+	}
+	title := fs.String("title", "", "`title` of the puzzle")
+	root := fs.String("root", defaultRoot(), "`directory` of the repository (default $"+rootEnv+" or the working directory)")
+	input := fs.String("input", "", "copy the input from the given `path` instead of downloading it")
+	session := fs.String("session", os.Getenv(sessionEnv), "session `cookie` used to download the input (default $"+sessionEnv+")")
+	baseURL := fs.String("url", workspace.DefaultBaseURL, "base `URL` the input is downloaded from")
+
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		args = append(args[1:len(args):len(args)], args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return newDayOptions{}, err
+	}
+	if fs.NArg() != 1 {
+		return newDayOptions{}, fmt.Errorf("expected a single day but got %d arguments", fs.NArg())
+	}
+
+	day, err := parseDay(fs.Arg(0))
+	if err != nil {
+		return newDayOptions{}, fmt.Errorf("invalid day: %w", err)
+	}
+
+	opts := newDayOptions{
+		day:  workspace.Day{Number: day, Title: *title},
+		root: *root,
+	}
+	switch {
+	case *input != "":
+		opts.fetcher = workspace.FileFetcher{Path: *input}
+	case *session != "":
+		opts.fetcher = workspace.HTTPFetcher{BaseURL: *baseURL, Session: *session}
+	default:
+		return newDayOptions{}, fmt.Errorf("either an input or a session must be given to get the input of the day")
+	}
+	return opts, nil
+}
+
+// parseDays parses a comma-separated list of days and ranges of days such as "1-10,12"
+func parseDays(daysString string) (util.Set[int], error) {
+	days := util.NewSet[int]()
+	if daysString == "" {
+		return days, nil
+	}
+
+	for _, daysRange := range strings.Split(daysString, ",") {
+		fromString, toString, isRange := strings.Cut(daysRange, "-")
+		if !isRange {
+			toString = fromString
+		}
+
+		from, err := parseDay(fromString)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseDay(toString)
+		if err != nil {
+			return nil, err
+		}
+		if from > to {
+			return nil, fmt.Errorf("invalid range %s: %d is greater than %d", daysRange, from, to)
+		}
+
+		for day := from; day <= to; day++ {
+			days.Add(day)
+		}
+	}
+	return days, nil
+}
+
+func parseDay(dayString string) (int, error) {
+	day, err := strconv.Atoi(strings.TrimSpace(dayString))
+	if err != nil {
+		return 0, fmt.Errorf("could not parse day %q: %w", dayString, err)
+	}
+	if err := validateDay(day); err != nil {
+		return 0, err
+	}
+	return day, nil
+}
+
+func validateDay(day int) error {
+	if day < firstDay || day > lastDay {
+		return fmt.Errorf("day %d must be between %d and %d", day, firstDay, lastDay)
+	}
+	return nil
+}
+
+// selects returns true if the given day must be run
+func (o runOptions) selects(day int) bool {
+	if o.skip.Contains(day) {
+		return false
+	}
+	return len(o.days) == 0 || o.days.Contains(day)
+}
+
+// runsPart returns true if the given part must be run
+func (o runOptions) runsPart(part int) bool {
+	return o.part == 0 || o.part == part
+}
+
+// parts returns the parts that must be run
+func (o runOptions) parts() []int {
+	var parts []int
+	for _, part := range []int{1, 2} {
+		if o.runsPart(part) {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// info returns where the messages that are not answers are written, so they don't mix with structured outputs
+func (o runOptions) info() io.Writer {
+	if o.output == "text" {
+		return os.Stdout
+	}
+	return os.Stderr
+}
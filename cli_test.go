@@ -0,0 +1,221 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/workspace"
+)
+
+func TestParseDaysShould(t *testing.T) {
+	t.Run("work for empty string", func(t *testing.T) {
+		days, err := parseDays("")
+		require.NoError(t, err)
+
+		assert.Empty(t, days)
+	})
+
+	t.Run("work for single days and ranges", func(t *testing.T) {
+		days, err := parseDays("1-3,7,10-10")
+		require.NoError(t, err)
+
+		assert.Equal(t, util.NewSet(1, 2, 3, 7, 10), days)
+	})
+
+	t.Run("fail for reversed range", func(t *testing.T) {
+		_, err := parseDays("10-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("fail for day out of bounds", func(t *testing.T) {
+		_, err := parseDays("0-3")
+		assert.Error(t, err)
+	})
+
+	t.Run("fail for invalid day", func(t *testing.T) {
+		_, err := parseDays("1,a")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseRunOptionsShould(t *testing.T) {
+	t.Run("select all days and parts by default", func(t *testing.T) {
+		opts, err := parseRunOptions("run", nil)
+		require.NoError(t, err)
+
+		assert.True(t, opts.selects(1))
+		assert.True(t, opts.selects(25))
+		assert.True(t, opts.runsPart(1))
+		assert.True(t, opts.runsPart(2))
+		assert.False(t, opts.includeDisabled)
+		assert.False(t, opts.checked)
+	})
+
+	t.Run("select a single day and part", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--day", "7", "--part", "2"})
+		require.NoError(t, err)
+
+		assert.True(t, opts.selects(7))
+		assert.False(t, opts.selects(8))
+		assert.False(t, opts.runsPart(1))
+		assert.True(t, opts.runsPart(2))
+	})
+
+	t.Run("skip days from a range", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--days", "1-10", "--skip", "3,5-6"})
+		require.NoError(t, err)
+
+		assert.True(t, opts.selects(1))
+		assert.False(t, opts.selects(3))
+		assert.False(t, opts.selects(5))
+		assert.False(t, opts.selects(6))
+		assert.True(t, opts.selects(10))
+		assert.False(t, opts.selects(11))
+	})
+
+	t.Run("fail for invalid part", func(t *testing.T) {
+		_, err := parseRunOptions("run", []string{"--part", "3"})
+		assert.Error(t, err)
+	})
+
+	t.Run("parse stats options", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--stats", "csv", "--stats-file", "stats.csv"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "csv", opts.statsFormat)
+		assert.Equal(t, "stats.csv", opts.statsFile)
+	})
+
+	t.Run("fail for invalid stats format", func(t *testing.T) {
+		_, err := parseRunOptions("run", []string{"--stats", "xml"})
+		assert.Error(t, err)
+	})
+
+	t.Run("parse timeout", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--timeout", "1m30s"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 90*time.Second, opts.timeout)
+	})
+
+	t.Run("enable checked arithmetic", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--checked"})
+		require.NoError(t, err)
+
+		assert.True(t, opts.checked)
+	})
+
+	t.Run("parse output format", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--output", "tap"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "tap", opts.output)
+	})
+
+	t.Run("fail for invalid output format", func(t *testing.T) {
+		_, err := parseRunOptions("run", []string{"--output", "yaml"})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail for invalid workers", func(t *testing.T) {
+		_, err := parseRunOptions("run", []string{"--workers", "0"})
+		assert.Error(t, err)
+	})
+
+	t.Run("parse answers path", func(t *testing.T) {
+		opts, err := parseRunOptions("verify", []string{"--answers", "other.json"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "other.json", opts.answersPath)
+	})
+
+	t.Run("resolve default answers path from the root", func(t *testing.T) {
+		opts, err := parseRunOptions("record", []string{"--root", "/tmp/aoc"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/tmp/aoc", opts.root)
+		assert.Equal(t, "/tmp/aoc/answers.json", opts.answersPath)
+	})
+
+	t.Run("parse input for a single day", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--day", "3", "--input", "-"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "-", opts.input)
+	})
+
+	t.Run("parse input cache options", func(t *testing.T) {
+		t.Setenv(cacheEnv, "")
+		opts, err := parseRunOptions("run", nil)
+		require.NoError(t, err)
+		assert.Empty(t, opts.cache)
+		assert.False(t, opts.refreshCache)
+
+		opts, err = parseRunOptions("run", []string{"--cache", "/tmp/inputs", "--refresh-cache"})
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/inputs", opts.cache)
+		assert.True(t, opts.refreshCache)
+	})
+
+	t.Run("parse explain", func(t *testing.T) {
+		opts, err := parseRunOptions("run", []string{"--day", "16", "--explain"})
+		require.NoError(t, err)
+
+		assert.True(t, opts.explain)
+	})
+
+	t.Run("fail for input without a single day", func(t *testing.T) {
+		_, err := parseRunOptions("run", []string{"--days", "1-2", "--input", "input.txt"})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail for answers path when running", func(t *testing.T) {
+		_, err := parseRunOptions("run", []string{"--answers", "other.json"})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail for unexpected arguments", func(t *testing.T) {
+		_, err := parseRunOptions("run", []string{"7"})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseNewDayOptionsShould(t *testing.T) {
+	t.Run("read the input from a file", func(t *testing.T) {
+		opts, err := parseNewDayOptions([]string{"--input", "input.txt", "--title", "Hill Climbing Algorithm", "12"})
+		require.NoError(t, err)
+
+		assert.Equal(t, workspace.Day{Number: 12, Title: "Hill Climbing Algorithm"}, opts.day)
+		assert.Equal(t, workspace.FileFetcher{Path: "input.txt"}, opts.fetcher)
+	})
+
+	t.Run("download the input with a session", func(t *testing.T) {
+		opts, err := parseNewDayOptions([]string{"5", "--session", "secret", "--url", "http://localhost:8080", "--root", "aoc"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, opts.day.Number)
+		assert.Equal(t, "aoc", opts.root)
+		assert.Equal(t, workspace.HTTPFetcher{BaseURL: "http://localhost:8080", Session: "secret"}, opts.fetcher)
+	})
+
+	t.Run("fail without input nor session", func(t *testing.T) {
+		t.Setenv(sessionEnv, "")
+
+		_, err := parseNewDayOptions([]string{"5"})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail without a day", func(t *testing.T) {
+		_, err := parseNewDayOptions([]string{"--input", "input.txt"})
+		assert.Error(t, err)
+	})
+
+	t.Run("fail for invalid day", func(t *testing.T) {
+		_, err := parseNewDayOptions([]string{"--input", "input.txt", "26"})
+		assert.Error(t, err)
+	})
+}
@@ -3,9 +3,11 @@ package day01
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"golang.org/x/exp/slices"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -21,11 +23,21 @@ type item struct {
 	calories int
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    1,
+		Title:     "Calorie Counting",
+		InputPath: "day01/day01.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
-	elvesString := strings.Split(input, "\n")
-
-	elves, err := parseElves(elvesString)
+	elves, err := parse.EachBlock(parse.Blocks(input), parseElf)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse elves: %w", err)
 	}
@@ -48,24 +60,12 @@ func (d Day) SolvePartTwo() (string, error) {
 	return fmt.Sprintf("%d", top1Calories+top2Calories+top3Calories), nil
 }
 
-func parseElves(elvesString []string) ([]elf, error) {
-	var elves []elf
-	i := 0
-	for i < len(elvesString) {
-		var items []item
-		for i < len(elvesString) && elvesString[i] != "" {
-			item, err := parseItem(elvesString[i])
-			if err != nil {
-				return nil, fmt.Errorf("could not parse item: %w", err)
-			}
-
-			items = append(items, item)
-			i++
-		}
-		i++
-		elves = append(elves, elf{items: items})
+func parseElf(block parse.Block) (elf, error) {
+	items, err := parse.EachIn(block, parseItem)
+	if err != nil {
+		return elf{}, fmt.Errorf("could not parse item: %w", err)
 	}
-	return elves, nil
+	return elf{items: items}, nil
 }
 
 func parseItem(itemString string) (item, error) {
@@ -3,6 +3,8 @@ package day02
 import (
 	"fmt"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -27,6 +29,18 @@ const (
 	rock     shape = "Rock"
 )
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    2,
+		Title:     "Rock Paper Scissors",
+		InputPath: "day02/day02.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	strategyGuideString := strings.Split(input, "\n")
@@ -2,7 +2,11 @@ package day03
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -19,6 +23,18 @@ type item struct {
 	id rune
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    3,
+		Title:     "Rucksack Reorganization",
+		InputPath: "day03/day03.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	rucksacksString := strings.Split(input, "\n")
@@ -108,19 +124,22 @@ func getCommonItemSum(rucksacks []rucksack) (int, error) {
 }
 
 func (r rucksack) findCommonItem() (item, error) {
-	firstCompartmentItems := make(map[item]struct{})
+	return firstCommonItem(r.secondCompartmentItems, util.NewSet(r.firstCompartmentItems...), "rucksack compartments")
+}
 
-	for _, item := range r.firstCompartmentItems {
-		firstCompartmentItems[item] = struct{}{}
-	}
+// items returns the items of both compartments in the order they are packed
+func (r rucksack) items() []item {
+	return append(slices.Clone(r.firstCompartmentItems), r.secondCompartmentItems...)
+}
 
-	for _, item := range r.secondCompartmentItems {
-		if _, ok := firstCompartmentItems[item]; ok {
+// firstCommonItem returns the first of the given items that is also in the common set
+func firstCommonItem(items []item, common util.Set[item], where string) (item, error) {
+	for _, item := range items {
+		if common.Contains(item) {
 			return item, nil
 		}
 	}
-
-	return item{}, fmt.Errorf("no common item in rucksack compartments")
+	return item{}, fmt.Errorf("no common item in %s", where)
 }
 
 func (i item) priority() int {
@@ -148,37 +167,6 @@ func getBadgeSum(rucksacks []rucksack) (int, error) {
 }
 
 func findBadge(rucksack1, rucksack2, rucksack3 rucksack) (item, error) {
-	commonItems := make(map[item]struct{})
-
-	firstRucksackItems := make(map[item]struct{})
-	for _, item := range rucksack1.firstCompartmentItems {
-		firstRucksackItems[item] = struct{}{}
-	}
-	for _, item := range rucksack1.secondCompartmentItems {
-		firstRucksackItems[item] = struct{}{}
-	}
-
-	for _, item := range rucksack2.firstCompartmentItems {
-		if _, ok := firstRucksackItems[item]; ok {
-			commonItems[item] = struct{}{}
-		}
-	}
-	for _, item := range rucksack2.secondCompartmentItems {
-		if _, ok := firstRucksackItems[item]; ok {
-			commonItems[item] = struct{}{}
-		}
-	}
-
-	for _, item := range rucksack3.firstCompartmentItems {
-		if _, ok := commonItems[item]; ok {
-			return item, nil
-		}
-	}
-	for _, item := range rucksack3.secondCompartmentItems {
-		if _, ok := commonItems[item]; ok {
-			return item, nil
-		}
-	}
-
-	return item{}, fmt.Errorf("no common item in the three rucksacks")
+	commonItems := util.NewSet(rucksack1.items()...).Intersection(util.NewSet(rucksack2.items()...))
+	return firstCommonItem(rucksack3.items(), commonItems, "the three rucksacks")
 }
@@ -97,3 +97,34 @@ func TestItemPriority(t *testing.T) {
 	assert.Equal(t, 27, item{id: 'A'}.priority())
 	assert.Equal(t, 52, item{id: 'Z'}.priority())
 }
+
+func TestFindCommonItemShould(t *testing.T) {
+	t.Run("return the first common item of the second compartment when there are several", func(t *testing.T) {
+		r := rucksack{firstCompartmentItems: buildItems("abc"), secondCompartmentItems: buildItems("xcba")}
+
+		common, err := r.findCommonItem()
+		require.NoError(t, err)
+
+		assert.Equal(t, item{id: 'c'}, common)
+	})
+
+	t.Run("fail when there is no common item", func(t *testing.T) {
+		r := rucksack{firstCompartmentItems: buildItems("abc"), secondCompartmentItems: buildItems("xyz")}
+
+		_, err := r.findCommonItem()
+		assert.EqualError(t, err, "no common item in rucksack compartments")
+	})
+}
+
+func TestFindBadgeShould(t *testing.T) {
+	t.Run("return the first common item of the third rucksack when there are several", func(t *testing.T) {
+		r1 := rucksack{firstCompartmentItems: buildItems("ab"), secondCompartmentItems: buildItems("cd")}
+		r2 := rucksack{firstCompartmentItems: buildItems("dc"), secondCompartmentItems: buildItems("ba")}
+		r3 := rucksack{firstCompartmentItems: buildItems("xd"), secondCompartmentItems: buildItems("ay")}
+
+		badge, err := findBadge(r1, r2, r3)
+		require.NoError(t, err)
+
+		assert.Equal(t, item{id: 'd'}, badge)
+	})
+}
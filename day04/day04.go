@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -21,6 +23,18 @@ type sectionIDRange struct {
 	start, end sectionID
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    4,
+		Title:     "Camp Cleanup",
+		InputPath: "day04/day04.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	assignmentsString := strings.Split(input, "\n")
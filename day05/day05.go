@@ -8,6 +8,7 @@ import (
 
 	"golang.org/x/exp/slices"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
@@ -36,6 +37,18 @@ type step struct {
 	to     stackID
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    5,
+		Title:     "Supply Stacks",
+		InputPath: "day05/day05.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	splitInput := strings.Split(input, "\n")
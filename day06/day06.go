@@ -2,6 +2,8 @@ package day06
 
 import (
 	"fmt"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 const (
@@ -16,6 +18,18 @@ type Day struct {
 
 type datastreamBuffer string
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    6,
+		Title:     "Tuning Trouble",
+		InputPath: "day06/day06.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	return &Day{
@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 const (
@@ -30,6 +32,18 @@ type Node struct {
 	children []*Node
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    7,
+		Title:     "No Space Left On Device",
+		InputPath: "day07/day07.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	terminalOutput := strings.Split(input, "\n")
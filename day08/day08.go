@@ -5,18 +5,32 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
 )
 
 // Day holds the data needed to solve part one and part two
 type Day struct {
-	grid [][]tree
+	grid util.Grid[tree]
 }
 
 type tree struct {
 	height int
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    8,
+		Title:     "Treetop Tree House",
+		InputPath: "day08/day08.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	gridString := strings.Split(input, "\n")
@@ -41,21 +55,11 @@ func (d Day) SolvePartTwo() (string, error) {
 	return fmt.Sprintf("%d", getHighestScenicScore(d.grid)), nil
 }
 
-func parseGrid(gridString []string) ([][]tree, error) {
-	grid := make([][]tree, 0, len(gridString))
-	for _, trees := range gridString {
-		row := make([]tree, 0, len(trees))
-		for _, treeRune := range trees {
-			tree, err := parseTree(treeRune)
-			if err != nil {
-				return nil, fmt.Errorf("could not parse tree: %w", err)
-			}
-
-			row = append(row, tree)
-		}
-		grid = append(grid, row)
+func parseGrid(gridString []string) (util.Grid[tree], error) {
+	grid, err := parse.Grid(gridString, parseTree)
+	if err != nil {
+		return util.Grid[tree]{}, fmt.Errorf("could not parse tree: %w", err)
 	}
-
 	return grid, nil
 }
 
@@ -68,126 +72,61 @@ func parseTree(treeRune rune) (tree, error) {
 	return tree{height: height}, nil
 }
 
-func visibleTrees(grid [][]tree) int {
+func visibleTrees(grid util.Grid[tree]) int {
 	visibleTrees := 0
-	for i := 0; i < len(grid); i++ {
-		for j := 0; j < len(grid[0]); j++ {
-			if isVisible(grid, i, j) {
-				visibleTrees += 1
-			}
+	for position := range grid.All() {
+		if isVisible(grid, position) {
+			visibleTrees += 1
 		}
 	}
 	return visibleTrees
 }
 
-func isVisible(grid [][]tree, i, j int) bool {
-	return isVisibleFromLeft(grid, i, j) ||
-		isVisibleFromRight(grid, i, j) ||
-		isVisibleFromTop(grid, i, j) ||
-		isVisibleFromBottom(grid, i, j)
-}
-
-func isVisibleFromLeft(grid [][]tree, i, j int) bool {
-	tree := grid[i][j]
-	for j > 0 && grid[i][j-1].isShorter(tree) {
-		j--
-	}
-	return j == 0
-}
-
-func isVisibleFromRight(grid [][]tree, i, j int) bool {
-	tree := grid[i][j]
-	for j < len(grid[0])-1 && grid[i][j+1].isShorter(tree) {
-		j++
-	}
-	return j == len(grid[0])-1
-}
-
-func isVisibleFromTop(grid [][]tree, i, j int) bool {
-	tree := grid[i][j]
-	for i > 0 && grid[i-1][j].isShorter(tree) {
-		i--
+func isVisible(grid util.Grid[tree], position util.Point2) bool {
+	for _, direction := range util.Compass4 {
+		if isVisibleFrom(grid, position, direction) {
+			return true
+		}
 	}
-	return i == 0
+	return false
 }
 
-func isVisibleFromBottom(grid [][]tree, i, j int) bool {
-	tree := grid[i][j]
-	for i < len(grid)-1 && grid[i+1][j].isShorter(tree) {
-		i++
+// isVisibleFrom returns true if all the trees between the position and the edge in the given direction are shorter
+func isVisibleFrom(grid util.Grid[tree], position util.Point2, direction util.Point2) bool {
+	tree := grid.At(position)
+	for _, other := range grid.Walk(position, direction) {
+		if !other.isShorter(tree) {
+			return false
+		}
 	}
-	return i == len(grid)-1
+	return true
 }
 
-func getHighestScenicScore(grid [][]tree) int {
+func getHighestScenicScore(grid util.Grid[tree]) int {
 	highestScenicScore := 0
-	for i := 0; i < len(grid); i++ {
-		for j := 0; j < len(grid[0]); j++ {
-			highestScenicScore = util.Max(highestScenicScore, scenicScore(grid, i, j))
-		}
+	for position := range grid.All() {
+		highestScenicScore = util.Max(highestScenicScore, scenicScore(grid, position))
 	}
 	return highestScenicScore
 }
 
-func scenicScore(grid [][]tree, i, j int) int {
-	return leftViewingDistance(grid, i, j) *
-		rightViewingDistance(grid, i, j) *
-		upViewingDistance(grid, i, j) *
-		downViewingDistance(grid, i, j)
-}
-
-func leftViewingDistance(grid [][]tree, i, j int) int {
-	if j == 0 {
-		return 0
+func scenicScore(grid util.Grid[tree], position util.Point2) int {
+	scenicScore := 1
+	for _, direction := range util.Compass4 {
+		scenicScore *= viewingDistance(grid, position, direction)
 	}
-
-	tree := grid[i][j]
-	viewingDistance := 1
-	for j > 1 && grid[i][j-1].isShorter(tree) {
-		j--
-		viewingDistance++
-	}
-	return viewingDistance
+	return scenicScore
 }
 
-func rightViewingDistance(grid [][]tree, i, j int) int {
-	if j == len(grid[0])-1 {
-		return 0
-	}
-
-	tree := grid[i][j]
-	viewingDistance := 1
-	for j < len(grid[0])-2 && grid[i][j+1].isShorter(tree) {
-		j++
-		viewingDistance++
-	}
-	return viewingDistance
-}
-
-func upViewingDistance(grid [][]tree, i, j int) int {
-	if i == 0 {
-		return 0
-	}
-
-	tree := grid[i][j]
-	viewingDistance := 1
-	for i > 1 && grid[i-1][j].isShorter(tree) {
-		i--
-		viewingDistance++
-	}
-	return viewingDistance
-}
-
-func downViewingDistance(grid [][]tree, i, j int) int {
-	if i == len(grid)-1 {
-		return 0
-	}
-
-	tree := grid[i][j]
-	viewingDistance := 1
-	for i < len(grid)-2 && grid[i+1][j].isShorter(tree) {
-		i++
+// viewingDistance returns the number of trees seen in the given direction until one that is not shorter or the edge
+func viewingDistance(grid util.Grid[tree], position util.Point2, direction util.Point2) int {
+	tree := grid.At(position)
+	viewingDistance := 0
+	for _, other := range grid.Walk(position, direction) {
 		viewingDistance++
+		if !other.isShorter(tree) {
+			break
+		}
 	}
 	return viewingDistance
 }
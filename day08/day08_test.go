@@ -5,17 +5,25 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
+func exampleGrid(t *testing.T) util.Grid[tree] {
+	grid, err := util.GridFromRows([][]tree{
+		{{3}, {0}, {3}, {7}, {3}},
+		{{2}, {5}, {5}, {1}, {2}},
+		{{6}, {5}, {3}, {3}, {2}},
+		{{3}, {3}, {5}, {4}, {9}},
+		{{3}, {5}, {3}, {9}, {0}},
+	})
+	require.NoError(t, err)
+	return grid
+}
+
 func TestNewDay(t *testing.T) {
 	expected := &Day{
-		grid: [][]tree{
-			{{3}, {0}, {3}, {7}, {3}},
-			{{2}, {5}, {5}, {1}, {2}},
-			{{6}, {5}, {3}, {3}, {2}},
-			{{3}, {3}, {5}, {4}, {9}},
-			{{3}, {5}, {3}, {9}, {0}},
-		},
+		grid: exampleGrid(t),
 	}
 	input := `30373
 25512
@@ -30,13 +38,7 @@ func TestNewDay(t *testing.T) {
 
 func TestSolvePartOne(t *testing.T) {
 	day := &Day{
-		grid: [][]tree{
-			{{3}, {0}, {3}, {7}, {3}},
-			{{2}, {5}, {5}, {1}, {2}},
-			{{6}, {5}, {3}, {3}, {2}},
-			{{3}, {3}, {5}, {4}, {9}},
-			{{3}, {5}, {3}, {9}, {0}},
-		},
+		grid: exampleGrid(t),
 	}
 
 	answer, err := day.SolvePartOne()
@@ -47,13 +49,7 @@ func TestSolvePartOne(t *testing.T) {
 
 func TestSolvePartTwo(t *testing.T) {
 	day := &Day{
-		grid: [][]tree{
-			{{3}, {0}, {3}, {7}, {3}},
-			{{2}, {5}, {5}, {1}, {2}},
-			{{6}, {5}, {3}, {3}, {2}},
-			{{3}, {3}, {5}, {4}, {9}},
-			{{3}, {5}, {3}, {9}, {0}},
-		},
+		grid: exampleGrid(t),
 	}
 
 	answer, err := day.SolvePartTwo()
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
@@ -37,6 +38,18 @@ type position struct {
 	x, y int
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    9,
+		Title:     "Rope Bridge",
+		InputPath: "day09/day09.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	motionsString := strings.Split(input, "\n")
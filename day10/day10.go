@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -39,6 +41,18 @@ type crt struct {
 	pixels [6][40]rune
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    10,
+		Title:     "Cathode-Ray Tube",
+		InputPath: "day10/day10.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	programString := strings.Split(input, "\n")
@@ -2,28 +2,32 @@ package day11
 
 import (
 	"fmt"
-	"regexp"
 	"slices"
 	"strconv"
-	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
 )
 
 // Day holds the data needed to solve part one and part two
 type Day struct {
 	monkeys []monkey
+	// arithmetic computes the worry levels, checking overflows if asked to
+	arithmetic util.Arithmetic
 }
 
-var (
-	// Regex matching a line of the form "  Starting items: 79, 98"
-	startingItemsRe = regexp.MustCompile(`^  Starting items: (.*)$`)
-	// Regex matching a line of the form "  Operation: new = old * 19"
-	operationRe = regexp.MustCompile(`^  Operation: new = old (.) (.*)$`)
-	// Regex matching a line of the form "  Test: divisible by 23"
-	testRe = regexp.MustCompile(`^  Test: divisible by (\d+)$`)
-	// Regex matching a line of the form "    If true: throw to monkey 2"
-	ifTrueRe = regexp.MustCompile(`^    If true: throw to monkey (\d+)$`)
-	// Regex matching a line of the form "    If false: throw to monkey 3"
-	ifFalseRe = regexp.MustCompile(`^    If false: throw to monkey (\d+)$`)
+const (
+	// startingItemsPattern matches a line of the form "  Starting items: 79, 98"
+	startingItemsPattern = "  Starting items: %s"
+	// operationPattern matches a line of the form "  Operation: new = old * 19"
+	operationPattern = "  Operation: new = old %c %s"
+	// testPattern matches a line of the form "  Test: divisible by 23"
+	testPattern = "  Test: divisible by %d"
+	// ifTruePattern matches a line of the form "    If true: throw to monkey 2"
+	ifTruePattern = "    If true: throw to monkey %d"
+	// ifFalsePattern matches a line of the form "    If false: throw to monkey 3"
+	ifFalsePattern = "    If false: throw to monkey %d"
 )
 
 type monkey struct {
@@ -54,16 +58,26 @@ type monkeyState struct {
 
 type reliefWorryLevelFn func(int) int
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    11,
+		Title:     "Monkey in the Middle",
+		InputPath: "day11/day11.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
-	lines := strings.Split(input, "\n")
-
-	monkeys, err := parseMonkeys(lines)
+	monkeys, err := parse.EachBlock(parse.Blocks(input), parseMonkey)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse monkeys: %w", err)
 	}
 
-	return &Day{monkeys: monkeys}, nil
+	return &Day{monkeys: monkeys, arithmetic: util.DefaultArithmetic}, nil
 }
 
 // SolvePartOne solves part one
@@ -72,7 +86,9 @@ func (d Day) SolvePartOne() (string, error) {
 	reliefWorryLevelFn := func(worryLevel int) int { return worryLevel / 3 }
 
 	for i := 0; i < 20; i++ {
-		monkeyState = d.playRound(monkeyState, reliefWorryLevelFn)
+		if err := d.playRound(monkeyState, reliefWorryLevelFn); err != nil {
+			return "", fmt.Errorf("could not play round %d: %w", i+1, err)
+		}
 	}
 	return fmt.Sprintf("%d", monkeyBusiness(monkeyState)), nil
 }
@@ -80,131 +96,87 @@ func (d Day) SolvePartOne() (string, error) {
 // SolvePartTwo solves part two
 func (d Day) SolvePartTwo() (string, error) {
 	monkeyState := newMonkeyState(d.monkeys)
-	totalModulo := d.computeTotalModulo()
+	totalModulo, err := d.computeTotalModulo()
+	if err != nil {
+		return "", fmt.Errorf("could not compute total modulo: %w", err)
+	}
 	reliefWorryLevelFn := func(worryLevel int) int { return worryLevel % totalModulo }
 
 	for i := 0; i < 10_000; i++ {
-		monkeyState = d.playRound(monkeyState, reliefWorryLevelFn)
-	}
-	return fmt.Sprintf("%d", monkeyBusiness(monkeyState)), nil
-}
-
-func parseMonkeys(lines []string) ([]monkey, error) {
-	var monkeys []monkey
-	for i := 0; i < len(lines); i += 7 {
-		monkey, err := parseMonkey(lines[i : i+6])
-		if err != nil {
-			return nil, fmt.Errorf("could not parse monkey: %w", err)
+		if err := d.playRound(monkeyState, reliefWorryLevelFn); err != nil {
+			return "", fmt.Errorf("could not play round %d: %w", i+1, err)
 		}
-
-		monkeys = append(monkeys, monkey)
 	}
-
-	return monkeys, nil
+	return fmt.Sprintf("%d", monkeyBusiness(monkeyState)), nil
 }
 
-func parseMonkey(lines []string) (monkey, error) {
-	matches := startingItemsRe.FindStringSubmatch(lines[1])
-	if len(matches) != 2 {
-		return monkey{}, fmt.Errorf("invalid starting items list: %s", lines[1])
+func parseMonkey(block parse.Block) (monkey, error) {
+	if len(block.Lines) != 6 {
+		return monkey{}, fmt.Errorf("monkey has %d lines instead of 6", len(block.Lines))
 	}
 
-	itemsString := strings.Split(matches[1], ",")
-	startingItems, err := parseStartingItems(itemsString)
+	startingItems, err := parseStartingItems(block.Lines[1])
 	if err != nil {
-		return monkey{}, fmt.Errorf("could not parse items: %w", err)
+		return monkey{}, parse.At(block.Line+1, fmt.Errorf("could not parse starting items: %w", err))
 	}
 
-	operation, err := parseOperation(lines[2])
+	operation, err := parseOperation(block.Lines[2])
 	if err != nil {
-		return monkey{}, fmt.Errorf("could not parse operation: %w", err)
+		return monkey{}, parse.At(block.Line+2, fmt.Errorf("could not parse operation: %w", err))
 	}
 
-	test, err := parseTest(lines[3], lines[4], lines[5])
-	if err != nil {
-		return monkey{}, fmt.Errorf("could not parse test: %w", err)
+	var test test
+	testLines := []struct {
+		pattern string
+		target  *int
+	}{
+		{testPattern, &test.divisibleBy},
+		{ifTruePattern, &test.monkeyIfTrue},
+		{ifFalsePattern, &test.monkeyIfFalse},
+	}
+	for i, line := range testLines {
+		if err := parse.Match(block.Lines[3+i], line.pattern, line.target); err != nil {
+			return monkey{}, parse.At(block.Line+3+i, fmt.Errorf("could not parse test: %w", err))
+		}
 	}
 
 	return monkey{startingItems: startingItems, operation: operation, test: test}, nil
 }
 
-func parseStartingItems(itemsString []string) ([]item, error) {
-	var items []item
-	for _, itemString := range itemsString {
-		item, err := parseItem(strings.TrimSpace(itemString))
-		if err != nil {
-			return nil, fmt.Errorf("could not parse item: %w", err)
-		}
-
-		items = append(items, item)
+func parseStartingItems(startingItemsString string) ([]item, error) {
+	var itemsString string
+	if err := parse.Match(startingItemsString, startingItemsPattern, &itemsString); err != nil {
+		return nil, err
 	}
-	return items, nil
-}
 
-func parseItem(itemString string) (item, error) {
-	worryLevel, err := strconv.Atoi(itemString)
+	worryLevels, err := parse.Ints(itemsString)
 	if err != nil {
-		return item{}, fmt.Errorf("invalid worry level value: %w", err)
+		return nil, fmt.Errorf("invalid worry level value: %w", err)
 	}
 
-	return item{worryLevel: worryLevel}, nil
+	items := make([]item, 0, len(worryLevels))
+	for _, worryLevel := range worryLevels {
+		items = append(items, item{worryLevel: worryLevel})
+	}
+	return items, nil
 }
 
 func parseOperation(operationString string) (operation, error) {
-	matches := operationRe.FindStringSubmatch(operationString)
-	if len(matches) != 3 {
-		return operation{}, fmt.Errorf("invalid operation format: %s", operationString)
+	var operator rune
+	var operandString string
+	if err := parse.Match(operationString, operationPattern, &operator, &operandString); err != nil {
+		return operation{}, err
 	}
 
-	operator := matches[1]
-
-	if matches[2] == "old" {
+	if operandString == "old" {
 		return operation{operator: "* old"}, nil
 	}
 
-	operand, err := strconv.Atoi(matches[2])
+	operand, err := strconv.Atoi(operandString)
 	if err != nil {
 		return operation{}, fmt.Errorf("could not parse operand: %w", err)
 	}
-	return operation{operator: operator, operand: operand}, nil
-}
-
-func parseTest(testString, monkeyIfTrueString, monkeyIfFalseString string) (test, error) {
-	matches := testRe.FindStringSubmatch(testString)
-	if len(matches) != 2 {
-		return test{}, fmt.Errorf("invalid test format: %s", testString)
-	}
-
-	divisibleBy, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return test{}, fmt.Errorf("could not parse divisible by: %w", err)
-	}
-
-	matches = ifTrueRe.FindStringSubmatch(monkeyIfTrueString)
-	if len(matches) != 2 {
-		return test{}, fmt.Errorf("invalid if true format: %s", testString)
-	}
-
-	monkeyIfTrue, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return test{}, fmt.Errorf("could not parse if true monkey: %w", err)
-	}
-
-	matches = ifFalseRe.FindStringSubmatch(monkeyIfFalseString)
-	if len(matches) != 2 {
-		return test{}, fmt.Errorf("invalid if false format: %s", testString)
-	}
-
-	monkeyIfFalse, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return test{}, fmt.Errorf("could not parse if false monkey: %w", err)
-	}
-
-	return test{
-		divisibleBy:   divisibleBy,
-		monkeyIfTrue:  monkeyIfTrue,
-		monkeyIfFalse: monkeyIfFalse,
-	}, nil
+	return operation{operator: string(operator), operand: operand}, nil
 }
 
 func newMonkeyState(monkeys []monkey) *monkeyState {
@@ -220,20 +192,25 @@ func newMonkeyState(monkeys []monkey) *monkeyState {
 	return &monkeyState{itemsHolding: itemsHolding, totalItemsInspected: totalItemsInspected}
 }
 
-func (d Day) playRound(monkeyState *monkeyState, reliefFn reliefWorryLevelFn) *monkeyState {
+func (d Day) playRound(monkeyState *monkeyState, reliefFn reliefWorryLevelFn) error {
 	for i := 0; i < len(d.monkeys); i++ {
-		monkeyState = d.executeTurn(i, monkeyState, reliefFn)
+		if err := d.executeTurn(i, monkeyState, reliefFn); err != nil {
+			return err
+		}
 	}
-	return monkeyState
+	return nil
 }
 
 func (d Day) executeTurn(
 	currentMonkey int,
 	state *monkeyState,
 	reliefFn reliefWorryLevelFn,
-) *monkeyState {
+) error {
 	for _, currentItem := range state.itemsHolding[currentMonkey] {
-		worryLevel := d.monkeys[currentMonkey].operation.apply(currentItem)
+		worryLevel, err := d.monkeys[currentMonkey].operation.apply(d.arithmetic, currentItem)
+		if err != nil {
+			return fmt.Errorf("monkey %d could not inspect item with worry level %d: %w", currentMonkey, currentItem, err)
+		}
 		worryLevel = reliefFn(worryLevel)
 
 		recipient := d.monkeys[currentMonkey].test.throwItemTo(worryLevel)
@@ -243,15 +220,17 @@ func (d Day) executeTurn(
 		state.totalItemsInspected[currentMonkey]++
 	}
 	state.itemsHolding[currentMonkey] = nil
-	return state
+	return nil
 }
 
-func (d Day) computeTotalModulo() int {
-	totalModulo := 1
-	for i := 0; i < len(d.monkeys); i++ {
-		totalModulo *= d.monkeys[i].test.divisibleBy
+// computeTotalModulo returns the smallest modulo that keeps the result of every test,
+// so worry levels can be reduced by it without changing where items are thrown
+func (d Day) computeTotalModulo() (int, error) {
+	divisors := make([]int, 0, len(d.monkeys))
+	for _, monkey := range d.monkeys {
+		divisors = append(divisors, monkey.test.divisibleBy)
 	}
-	return totalModulo
+	return util.LCM(divisors...)
 }
 
 func monkeyBusiness(monkeyState *monkeyState) int {
@@ -260,16 +239,17 @@ func monkeyBusiness(monkeyState *monkeyState) int {
 	return monkeyState.totalItemsInspected[n-1] * monkeyState.totalItemsInspected[n-2]
 }
 
-func (o operation) apply(worryLevel int) int {
+// apply returns the new worry level, failing if it overflows when the arithmetic is checked
+func (o operation) apply(a util.Arithmetic, worryLevel int) (int, error) {
 	if o.operator == "* old" {
-		return worryLevel * worryLevel
+		return a.Mul(worryLevel, worryLevel)
 	}
 
 	if o.operator == "*" {
-		return worryLevel * o.operand
+		return a.Mul(worryLevel, o.operand)
 	}
 
-	return worryLevel + o.operand
+	return a.Add(worryLevel, o.operand)
 }
 
 func (t test) throwItemTo(worryLevel int) int {
@@ -1,10 +1,13 @@
 package day11
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 func TestNewDay(t *testing.T) {
@@ -116,3 +119,26 @@ func TestSolvePartTwo(t *testing.T) {
 
 	assert.Equal(t, "2713310158", answer)
 }
+
+func TestSolvePartOneShould(t *testing.T) {
+	t.Run("fail with the monkey and item whose worry level overflows when checked", func(t *testing.T) {
+		day := &Day{
+			monkeys: []monkey{
+				{[]item{{79}},
+					operation{operator: "*", operand: math.MaxInt / 2},
+					test{divisibleBy: 2, monkeyIfTrue: 1, monkeyIfFalse: 1},
+				},
+				{nil,
+					operation{operator: "+", operand: 1},
+					test{divisibleBy: 2, monkeyIfTrue: 0, monkeyIfFalse: 0},
+				},
+			},
+			arithmetic: util.Arithmetic{Checked: true},
+		}
+
+		_, err := day.SolvePartOne()
+
+		assert.ErrorIs(t, err, util.ErrOverflow)
+		assert.ErrorContains(t, err, "monkey 0 could not inspect item with worry level 79")
+	})
+}
@@ -1,27 +1,34 @@
 package day12
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"math"
 	"strings"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
+	"github.com/OctaviPascual/AdventOfCode2022/util/search"
 )
 
 // Day holds the data needed to solve part one and part two
 type Day struct {
-	heightmap [][]elevation
+	heightmap util.Grid[elevation]
 }
 
 type elevation rune
 
-type positionState struct {
-	position position
-	steps    int
-}
-
-type position struct {
-	i, j int
+func init() {
+	registry.Register(registry.Day{
+		Number:    12,
+		Title:     "Hill Climbing Algorithm",
+		InputPath: "day12/day12.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
 }
 
 // NewDay returns a new Day that solves part one and two for the given input
@@ -40,12 +47,22 @@ func NewDay(input string) (*Day, error) {
 
 // SolvePartOne solves part one
 func (d Day) SolvePartOne() (string, error) {
+	return d.SolvePartOneContext(context.Background())
+}
+
+// SolvePartTwo solves part two
+func (d Day) SolvePartTwo() (string, error) {
+	return d.SolvePartTwoContext(context.Background())
+}
+
+// SolvePartOneContext solves part one, stopping the search when the context is done
+func (d Day) SolvePartOneContext(ctx context.Context) (string, error) {
 	startingPosition, err := d.getStartingPosition()
 	if err != nil {
 		return "", fmt.Errorf("could not get starting position: %w", err)
 	}
 
-	steps, err := d.stepsToFinalPosition(startingPosition)
+	steps, err := d.stepsToFinalPosition(ctx, startingPosition)
 	if err != nil {
 		return "", fmt.Errorf("could not get number of steps: %w", err)
 	}
@@ -53,153 +70,82 @@ func (d Day) SolvePartOne() (string, error) {
 	return fmt.Sprintf("%d", steps), nil
 }
 
-// SolvePartTwo solves part two
-func (d Day) SolvePartTwo() (string, error) {
-	startingPositions := d.getStartingPositions()
-
-	minSteps := math.MaxInt
-	for _, startingPosition := range startingPositions {
-		steps, err := d.stepsToFinalPosition(startingPosition)
-		if err != nil {
-			continue
-		}
-
-		minSteps = min(minSteps, steps)
-	}
-
-	if minSteps == math.MaxInt {
-		return "", fmt.Errorf("final position is unreachable")
-	}
-
-	return fmt.Sprintf("%d", minSteps), nil
-}
-
-func parseHeightmap(heightmapString []string) ([][]elevation, error) {
-	heightmap := make([][]elevation, 0, len(heightmapString))
-	for _, elevations := range heightmapString {
-		row := make([]elevation, 0, len(elevations))
-		for _, elevationRune := range elevations {
-			row = append(row, elevation(elevationRune))
-		}
-		heightmap = append(heightmap, row)
+// SolvePartTwoContext solves part two, stopping the search when the context is done
+func (d Day) SolvePartTwoContext(ctx context.Context) (string, error) {
+	// Searching from all the starting positions at once finds the closest one to the final position
+	steps, err := d.stepsToFinalPosition(ctx, d.getStartingPositions()...)
+	if err != nil {
+		return "", fmt.Errorf("could not get number of steps: %w", err)
 	}
 
-	return heightmap, nil
+	return fmt.Sprintf("%d", steps), nil
 }
 
-func (d Day) stepsToFinalPosition(startingPosition position) (int, error) {
-	visited := util.NewSet[position]()
-	queue := []positionState{{position: startingPosition, steps: 0}}
-	for len(queue) > 0 {
-		state := queue[0]
-		queue = queue[1:]
-
-		if visited.Contains(state.position) {
-			continue
-		}
-		visited.Add(state.position)
-
-		if d.isFinalPosition(state.position) {
-			return state.steps, nil
-		}
-
-		nextPositions := d.getNextPositions(state)
-		queue = append(queue, nextPositions...)
-	}
-	return 0, fmt.Errorf("could not reach final position")
+func parseHeightmap(heightmapString []string) (util.Grid[elevation], error) {
+	return parse.Grid(heightmapString, func(elevationRune rune) (elevation, error) {
+		return elevation(elevationRune), nil
+	})
 }
 
-func (d Day) getNextPositions(state positionState) []positionState {
-	var nextPositions []positionState
-
-	steps := state.steps + 1
-
-	up := position{i: state.position.i - 1, j: state.position.j}
-	if d.isAccessible(state.position, up) {
-		nextPositions = append(nextPositions, positionState{position: up, steps: steps})
-	}
-
-	down := position{i: state.position.i + 1, j: state.position.j}
-	if d.isAccessible(state.position, down) {
-		nextPositions = append(nextPositions, positionState{position: down, steps: steps})
+func (d Day) stepsToFinalPosition(ctx context.Context, startingPositions ...util.Point2) (int, error) {
+	result, err := search.BFS(ctx, d.getNextPositions, d.isFinalPosition, startingPositions...)
+	if errors.Is(err, search.ErrNotFound) {
+		return 0, fmt.Errorf("final position is unreachable")
 	}
-
-	right := position{i: state.position.i, j: state.position.j + 1}
-	if d.isAccessible(state.position, right) {
-		nextPositions = append(nextPositions, positionState{position: right, steps: steps})
+	if err != nil {
+		return 0, err
 	}
+	return result.Cost, nil
+}
 
-	left := position{i: state.position.i, j: state.position.j - 1}
-	if d.isAccessible(state.position, left) {
-		nextPositions = append(nextPositions, positionState{position: left, steps: steps})
+func (d Day) getNextPositions(current util.Point2) []util.Point2 {
+	var nextPositions []util.Point2
+	for next := range d.heightmap.Neighbours4(current) {
+		if d.isAccessible(current, next) {
+			nextPositions = append(nextPositions, next)
+		}
 	}
-
 	return nextPositions
 }
 
-func (d Day) isAccessible(current position, next position) bool {
-	n := len(d.heightmap)
-	m := len(d.heightmap[0])
-	if next.i < 0 || next.i >= n || next.j < 0 || next.j >= m {
-		return false
-	}
-
+func (d Day) isAccessible(current util.Point2, next util.Point2) bool {
 	if d.isStartingPosition(current) {
-		return elevation('a')+1 >= d.heightmap[next.i][next.j]
+		return elevation('a')+1 >= d.heightmap.At(next)
 	}
 
 	if d.isFinalPosition(next) {
-		return d.heightmap[current.i][current.j]+1 >= elevation('z')
+		return d.heightmap.At(current)+1 >= elevation('z')
 	}
 
-	return d.heightmap[current.i][current.j]+1 >= d.heightmap[next.i][next.j]
+	return d.heightmap.At(current)+1 >= d.heightmap.At(next)
 }
 
-func (d Day) isStartingPosition(position position) bool {
+func (d Day) isStartingPosition(position util.Point2) bool {
 	return d.isPosition(position, elevation('S'))
 }
 
-func (d Day) isFinalPosition(position position) bool {
+func (d Day) isFinalPosition(position util.Point2) bool {
 	return d.isPosition(position, elevation('E'))
 }
 
-func (d Day) isPosition(position position, elevation elevation) bool {
-	n := len(d.heightmap)
-	m := len(d.heightmap[0])
-
-	if position.i < 0 || position.i >= n || position.j < 0 || position.j >= m {
-		return false
-	}
-
-	return d.heightmap[position.i][position.j] == elevation
+func (d Day) isPosition(position util.Point2, elevation elevation) bool {
+	actual, ok := d.heightmap.Get(position)
+	return ok && actual == elevation
 }
 
-func (d Day) getStartingPosition() (position, error) {
-	n := len(d.heightmap)
-	m := len(d.heightmap[0])
-
-	for i := 0; i < n; i++ {
-		for j := 0; j < m; j++ {
-			position := position{i: i, j: j}
-			if d.isStartingPosition(position) {
-				return position, nil
-			}
-		}
+func (d Day) getStartingPosition() (util.Point2, error) {
+	position, ok := d.heightmap.Find(func(e elevation) bool { return e == elevation('S') })
+	if !ok {
+		return util.Point2{}, fmt.Errorf("starting position S not found")
 	}
-	return position{}, fmt.Errorf("starting position S not found")
+	return position, nil
 }
 
-func (d Day) getStartingPositions() []position {
-	n := len(d.heightmap)
-	m := len(d.heightmap[0])
-
-	var startingPositions []position
-	for i := 0; i < n; i++ {
-		for j := 0; j < m; j++ {
-			position := position{i: i, j: j}
-			if d.isStartingPosition(position) || d.heightmap[i][j] == 'a' {
-				startingPositions = append(startingPositions, position)
-			}
+func (d Day) getStartingPositions() []util.Point2 {
+	var startingPositions []util.Point2
+	for position, elevation := range d.heightmap.All() {
+		if elevation == 'S' || elevation == 'a' {
+			startingPositions = append(startingPositions, position)
 		}
 	}
 	return startingPositions
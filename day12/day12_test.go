@@ -1,21 +1,30 @@
 package day12
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
+func exampleHeightmap(t *testing.T) util.Grid[elevation] {
+	heightmap, err := util.GridFromRows([][]elevation{
+		{elevation('S'), elevation('a'), elevation('b'), elevation('q'), elevation('p'), elevation('o'), elevation('n'), elevation('m')},
+		{elevation('a'), elevation('b'), elevation('c'), elevation('r'), elevation('y'), elevation('x'), elevation('x'), elevation('l')},
+		{elevation('a'), elevation('c'), elevation('c'), elevation('s'), elevation('z'), elevation('E'), elevation('x'), elevation('k')},
+		{elevation('a'), elevation('c'), elevation('c'), elevation('t'), elevation('u'), elevation('v'), elevation('w'), elevation('j')},
+		{elevation('a'), elevation('b'), elevation('d'), elevation('e'), elevation('f'), elevation('g'), elevation('h'), elevation('i')},
+	})
+	require.NoError(t, err)
+	return heightmap
+}
+
 func TestNewDay(t *testing.T) {
 	expected := &Day{
-		[][]elevation{
-			{elevation('S'), elevation('a'), elevation('b'), elevation('q'), elevation('p'), elevation('o'), elevation('n'), elevation('m')},
-			{elevation('a'), elevation('b'), elevation('c'), elevation('r'), elevation('y'), elevation('x'), elevation('x'), elevation('l')},
-			{elevation('a'), elevation('c'), elevation('c'), elevation('s'), elevation('z'), elevation('E'), elevation('x'), elevation('k')},
-			{elevation('a'), elevation('c'), elevation('c'), elevation('t'), elevation('u'), elevation('v'), elevation('w'), elevation('j')},
-			{elevation('a'), elevation('b'), elevation('d'), elevation('e'), elevation('f'), elevation('g'), elevation('h'), elevation('i')},
-		},
+		exampleHeightmap(t),
 	}
 	input := `Sabqponm
 abcryxxl
@@ -30,13 +39,7 @@ abdefghi`
 
 func TestSolvePartOne(t *testing.T) {
 	day := &Day{
-		[][]elevation{
-			{elevation('S'), elevation('a'), elevation('b'), elevation('q'), elevation('p'), elevation('o'), elevation('n'), elevation('m')},
-			{elevation('a'), elevation('b'), elevation('c'), elevation('r'), elevation('y'), elevation('x'), elevation('x'), elevation('l')},
-			{elevation('a'), elevation('c'), elevation('c'), elevation('s'), elevation('z'), elevation('E'), elevation('x'), elevation('k')},
-			{elevation('a'), elevation('c'), elevation('c'), elevation('t'), elevation('u'), elevation('v'), elevation('w'), elevation('j')},
-			{elevation('a'), elevation('b'), elevation('d'), elevation('e'), elevation('f'), elevation('g'), elevation('h'), elevation('i')},
-		},
+		exampleHeightmap(t),
 	}
 
 	answer, err := day.SolvePartOne()
@@ -47,13 +50,7 @@ func TestSolvePartOne(t *testing.T) {
 
 func TestSolvePartTwo(t *testing.T) {
 	day := &Day{
-		[][]elevation{
-			{elevation('S'), elevation('a'), elevation('b'), elevation('q'), elevation('p'), elevation('o'), elevation('n'), elevation('m')},
-			{elevation('a'), elevation('b'), elevation('c'), elevation('r'), elevation('y'), elevation('x'), elevation('x'), elevation('l')},
-			{elevation('a'), elevation('c'), elevation('c'), elevation('s'), elevation('z'), elevation('E'), elevation('x'), elevation('k')},
-			{elevation('a'), elevation('c'), elevation('c'), elevation('t'), elevation('u'), elevation('v'), elevation('w'), elevation('j')},
-			{elevation('a'), elevation('b'), elevation('d'), elevation('e'), elevation('f'), elevation('g'), elevation('h'), elevation('i')},
-		},
+		exampleHeightmap(t),
 	}
 
 	answer, err := day.SolvePartTwo()
@@ -61,3 +58,21 @@ func TestSolvePartTwo(t *testing.T) {
 
 	assert.Equal(t, "29", answer)
 }
+
+func TestSolveContextShould(t *testing.T) {
+	day := &Day{
+		exampleHeightmap(t),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("stop part one when the context is done", func(t *testing.T) {
+		_, err := day.SolvePartOneContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("stop part two when the context is done", func(t *testing.T) {
+		_, err := day.SolvePartTwoContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
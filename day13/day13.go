@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -33,6 +35,18 @@ const (
 	keepChecking comparisonOutcome = "keep checking"
 )
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    13,
+		Title:     "Distress Signal",
+		InputPath: "day13/day13.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	packetPairsString := strings.Split(input, "\n")
@@ -2,18 +2,16 @@ package day14
 
 import (
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 // Day holds the data needed to solve part one and part two
 type Day struct {
-	cave map[position]material
-}
-
-type position struct {
-	x, y int
+	cave *util.SparseGrid[material]
 }
 
 type material rune
@@ -24,9 +22,21 @@ const (
 )
 
 var (
-	sandSource = position{x: 500, y: 0}
+	sandSource = util.Point2{X: 500, Y: 0}
 )
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    14,
+		Title:     "Regolith Reservoir",
+		InputPath: "day14/day14.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	pathsString := strings.Split(input, "\n")
@@ -53,8 +63,8 @@ func (d Day) SolvePartTwo() (string, error) {
 	return fmt.Sprintf("%d", unitsOfRestingSand), nil
 }
 
-func parsePaths(pathsString []string) (map[position]material, error) {
-	cave := make(map[position]material)
+func parsePaths(pathsString []string) (*util.SparseGrid[material], error) {
+	cave := util.NewSparseGrid[material]()
 	for _, pathString := range pathsString {
 		err := parsePath(cave, pathString)
 		if err != nil {
@@ -64,7 +74,7 @@ func parsePaths(pathsString []string) (map[position]material, error) {
 	return cave, nil
 }
 
-func parsePath(cave map[position]material, pathString string) error {
+func parsePath(cave *util.SparseGrid[material], pathString string) error {
 	positionString := strings.Split(pathString, " -> ")
 	for i := 0; i < len(positionString)-1; i++ {
 		err := parseLineOfRock(cave, positionString[i], positionString[i+1])
@@ -75,7 +85,7 @@ func parsePath(cave map[position]material, pathString string) error {
 	return nil
 }
 
-func parseLineOfRock(cave map[position]material, startString, endString string) error {
+func parseLineOfRock(cave *util.SparseGrid[material], startString, endString string) error {
 	start, err := parsePosition(startString)
 	if err != nil {
 		return fmt.Errorf("coud not parse start position: %w", err)
@@ -86,20 +96,20 @@ func parseLineOfRock(cave map[position]material, startString, endString string)
 		return fmt.Errorf("coud not parse end position: %w", err)
 	}
 
-	if start.x == end.x {
-		minY := min(start.y, end.y)
-		maxY := max(start.y, end.y)
+	if start.X == end.X {
+		minY := min(start.Y, end.Y)
+		maxY := max(start.Y, end.Y)
 		for y := minY; y <= maxY; y++ {
-			cave[position{x: start.x, y: y}] = rock
+			cave.Set(util.Point2{X: start.X, Y: y}, rock)
 		}
 		return nil
 	}
 
-	if start.y == end.y {
-		minX := min(start.x, end.x)
-		maxX := max(start.x, end.x)
+	if start.Y == end.Y {
+		minX := min(start.X, end.X)
+		maxX := max(start.X, end.X)
 		for x := minX; x <= maxX; x++ {
-			cave[position{x: x, y: start.y}] = rock
+			cave.Set(util.Point2{X: x, Y: start.Y}, rock)
 		}
 		return nil
 	}
@@ -107,24 +117,24 @@ func parseLineOfRock(cave map[position]material, startString, endString string)
 	return fmt.Errorf("start (%s) and end (%s) don't form a straight line", startString, endString)
 }
 
-func parsePosition(positionString string) (position, error) {
+func parsePosition(positionString string) (util.Point2, error) {
 	xy := strings.Split(positionString, ",")
 
 	if len(xy) != 2 {
-		return position{}, fmt.Errorf("invalid position format: %s", positionString)
+		return util.Point2{}, fmt.Errorf("invalid position format: %s", positionString)
 	}
 
 	x, err := strconv.Atoi(xy[0])
 	if err != nil {
-		return position{}, fmt.Errorf("invalid x value: %w", err)
+		return util.Point2{}, fmt.Errorf("invalid x value: %w", err)
 	}
 
 	y, err := strconv.Atoi(xy[1])
 	if err != nil {
-		return position{}, fmt.Errorf("invalid y value: %w", err)
+		return util.Point2{}, fmt.Errorf("invalid y value: %w", err)
 	}
 
-	return position{x: x, y: y}, nil
+	return util.Point2{X: x, Y: y}, nil
 }
 
 func (d Day) pourSand() {
@@ -133,7 +143,7 @@ func (d Day) pourSand() {
 	for !isFlowingIntoAbyss(sandPosition, maxY) {
 		nextPosition := fall(sandPosition, d.cave)
 		if nextPosition == sandPosition {
-			d.cave[sandPosition] = sand
+			d.cave.Set(sandPosition, sand)
 			sandPosition = sandSource
 			continue
 		}
@@ -144,10 +154,10 @@ func (d Day) pourSand() {
 func (d Day) pourSandWithFloor() {
 	maxY := getMaxY(d.cave)
 	sandPosition := sandSource
-	for d.cave[sandSource] != sand {
+	for !d.cave.Contains(sandSource) {
 		nextPosition := fall(sandPosition, d.cave)
 		if hasReachedFloor(nextPosition, maxY) || nextPosition == sandPosition {
-			d.cave[sandPosition] = sand
+			d.cave.Set(sandPosition, sand)
 			sandPosition = sandSource
 			continue
 		}
@@ -155,36 +165,24 @@ func (d Day) pourSandWithFloor() {
 	}
 }
 
-func fall(sandPosition position, cave map[position]material) position {
-	down := position{x: sandPosition.x, y: sandPosition.y + 1}
-	if _, ok := cave[down]; !ok {
-		return down
-	}
-
-	downLeft := position{x: sandPosition.x - 1, y: sandPosition.y + 1}
-	if _, ok := cave[downLeft]; !ok {
-		return downLeft
-	}
-
-	downRight := position{x: sandPosition.x + 1, y: sandPosition.y + 1}
-	if _, ok := cave[downRight]; !ok {
-		return downRight
+func fall(sandPosition util.Point2, cave *util.SparseGrid[material]) util.Point2 {
+	for _, direction := range []util.Point2{util.South, util.SouthWest, util.SouthEast} {
+		next := sandPosition.Add(direction)
+		if !cave.Contains(next) {
+			return next
+		}
 	}
-
 	return sandPosition
 }
 
-func getMaxY(cave map[position]material) int {
-	maxY := math.MinInt
-	for position := range cave {
-		maxY = max(maxY, position.y)
-	}
-	return maxY
+func getMaxY(cave *util.SparseGrid[material]) int {
+	_, max, _ := cave.Bounds()
+	return max.Y
 }
 
-func getUnitsOfRestingSand(cave map[position]material) int {
+func getUnitsOfRestingSand(cave *util.SparseGrid[material]) int {
 	unitsOfRestingSand := 0
-	for _, m := range cave {
+	for _, m := range cave.All() {
 		if m == sand {
 			unitsOfRestingSand++
 		}
@@ -192,10 +190,10 @@ func getUnitsOfRestingSand(cave map[position]material) int {
 	return unitsOfRestingSand
 }
 
-func isFlowingIntoAbyss(sandPosition position, maxY int) bool {
-	return sandPosition.y >= maxY
+func isFlowingIntoAbyss(sandPosition util.Point2, maxY int) bool {
+	return sandPosition.Y >= maxY
 }
 
-func hasReachedFloor(sandPosition position, maxY int) bool {
-	return sandPosition.y == maxY+2
+func hasReachedFloor(sandPosition util.Point2, maxY int) bool {
+	return sandPosition.Y == maxY+2
 }
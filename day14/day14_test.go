@@ -5,25 +5,35 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
+func newCave(rocks []util.Point2) *util.SparseGrid[material] {
+	cave := util.NewSparseGrid[material]()
+	for _, p := range rocks {
+		cave.Set(p, rock)
+	}
+	return cave
+}
+
 func TestNewDay(t *testing.T) {
 	expected := &Day{
-		cave: map[position]material{
+		cave: newCave([]util.Point2{
 			// 498,4 -> 498,6
-			position{x: 498, y: 4}: rock, position{x: 498, y: 5}: rock, position{x: 498, y: 6}: rock,
+			{X: 498, Y: 4}, {X: 498, Y: 5}, {X: 498, Y: 6},
 			// 498,6 -> 496,6
-			position{x: 496, y: 6}: rock, position{x: 497, y: 6}: rock, position{x: 498, y: 6}: rock,
+			{X: 496, Y: 6}, {X: 497, Y: 6}, {X: 498, Y: 6},
 			// 503,4 -> 502,4
-			position{x: 502, y: 4}: rock, position{x: 503, y: 4}: rock,
+			{X: 502, Y: 4}, {X: 503, Y: 4},
 			// 502,4 -> 502,9
-			position{x: 502, y: 4}: rock, position{x: 502, y: 5}: rock, position{x: 502, y: 6}: rock,
-			position{x: 502, y: 7}: rock, position{x: 502, y: 8}: rock, position{x: 502, y: 9}: rock,
+			{X: 502, Y: 4}, {X: 502, Y: 5}, {X: 502, Y: 6},
+			{X: 502, Y: 7}, {X: 502, Y: 8}, {X: 502, Y: 9},
 			// 502,9 -> 494,9
-			position{x: 494, y: 9}: rock, position{x: 495, y: 9}: rock, position{x: 496, y: 9}: rock,
-			position{x: 497, y: 9}: rock, position{x: 498, y: 9}: rock, position{x: 499, y: 9}: rock,
-			position{x: 500, y: 9}: rock, position{x: 501, y: 9}: rock, position{x: 502, y: 9}: rock,
-		},
+			{X: 494, Y: 9}, {X: 495, Y: 9}, {X: 496, Y: 9},
+			{X: 497, Y: 9}, {X: 498, Y: 9}, {X: 499, Y: 9},
+			{X: 500, Y: 9}, {X: 501, Y: 9}, {X: 502, Y: 9},
+		}),
 	}
 	input := `498,4 -> 498,6 -> 496,6
 503,4 -> 502,4 -> 502,9 -> 494,9`
@@ -35,16 +45,16 @@ func TestNewDay(t *testing.T) {
 
 func TestSolvePartOne(t *testing.T) {
 	day := &Day{
-		cave: map[position]material{
-			position{x: 498, y: 4}: rock, position{x: 498, y: 5}: rock, position{x: 498, y: 6}: rock,
-			position{x: 496, y: 6}: rock, position{x: 497, y: 6}: rock, position{x: 498, y: 6}: rock,
-			position{x: 502, y: 4}: rock, position{x: 503, y: 4}: rock,
-			position{x: 502, y: 4}: rock, position{x: 502, y: 5}: rock, position{x: 502, y: 6}: rock,
-			position{x: 502, y: 7}: rock, position{x: 502, y: 8}: rock, position{x: 502, y: 9}: rock,
-			position{x: 494, y: 9}: rock, position{x: 495, y: 9}: rock, position{x: 496, y: 9}: rock,
-			position{x: 497, y: 9}: rock, position{x: 498, y: 9}: rock, position{x: 499, y: 9}: rock,
-			position{x: 500, y: 9}: rock, position{x: 501, y: 9}: rock, position{x: 502, y: 9}: rock,
-		},
+		cave: newCave([]util.Point2{
+			{X: 498, Y: 4}, {X: 498, Y: 5}, {X: 498, Y: 6},
+			{X: 496, Y: 6}, {X: 497, Y: 6}, {X: 498, Y: 6},
+			{X: 502, Y: 4}, {X: 503, Y: 4},
+			{X: 502, Y: 4}, {X: 502, Y: 5}, {X: 502, Y: 6},
+			{X: 502, Y: 7}, {X: 502, Y: 8}, {X: 502, Y: 9},
+			{X: 494, Y: 9}, {X: 495, Y: 9}, {X: 496, Y: 9},
+			{X: 497, Y: 9}, {X: 498, Y: 9}, {X: 499, Y: 9},
+			{X: 500, Y: 9}, {X: 501, Y: 9}, {X: 502, Y: 9},
+		}),
 	}
 
 	answer, err := day.SolvePartOne()
@@ -55,16 +65,16 @@ func TestSolvePartOne(t *testing.T) {
 
 func TestSolvePartTwo(t *testing.T) {
 	day := &Day{
-		cave: map[position]material{
-			position{x: 498, y: 4}: rock, position{x: 498, y: 5}: rock, position{x: 498, y: 6}: rock,
-			position{x: 496, y: 6}: rock, position{x: 497, y: 6}: rock, position{x: 498, y: 6}: rock,
-			position{x: 502, y: 4}: rock, position{x: 503, y: 4}: rock,
-			position{x: 502, y: 4}: rock, position{x: 502, y: 5}: rock, position{x: 502, y: 6}: rock,
-			position{x: 502, y: 7}: rock, position{x: 502, y: 8}: rock, position{x: 502, y: 9}: rock,
-			position{x: 494, y: 9}: rock, position{x: 495, y: 9}: rock, position{x: 496, y: 9}: rock,
-			position{x: 497, y: 9}: rock, position{x: 498, y: 9}: rock, position{x: 499, y: 9}: rock,
-			position{x: 500, y: 9}: rock, position{x: 501, y: 9}: rock, position{x: 502, y: 9}: rock,
-		},
+		cave: newCave([]util.Point2{
+			{X: 498, Y: 4}, {X: 498, Y: 5}, {X: 498, Y: 6},
+			{X: 496, Y: 6}, {X: 497, Y: 6}, {X: 498, Y: 6},
+			{X: 502, Y: 4}, {X: 503, Y: 4},
+			{X: 502, Y: 4}, {X: 502, Y: 5}, {X: 502, Y: 6},
+			{X: 502, Y: 7}, {X: 502, Y: 8}, {X: 502, Y: 9},
+			{X: 494, Y: 9}, {X: 495, Y: 9}, {X: 496, Y: 9},
+			{X: 497, Y: 9}, {X: 498, Y: 9}, {X: 499, Y: 9},
+			{X: 500, Y: 9}, {X: 501, Y: 9}, {X: 502, Y: 9},
+		}),
 	}
 
 	answer, err := day.SolvePartTwo()
@@ -3,12 +3,11 @@ package day15
 import (
 	"cmp"
 	"fmt"
-	"regexp"
 	"slices"
-	"strconv"
-	"strings"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -17,12 +16,14 @@ type Day struct {
 	maxY    int
 }
 
-const maxY = 4_000_000
+const (
+	maxY = 4_000_000
 
-var (
-	// Regex matching a line of the form "Sensor at x=2, y=18: closest beacon is at x=-2, y=15"
-	sensorRe = regexp.MustCompile(`^Sensor at x=(|-?\d+), y=(|-?\d+): closest beacon is at x=(|-?\d+), y=(|-?\d+)$`)
+	// sensorPattern matches a line of the form "Sensor at x=2, y=18: closest beacon is at x=-2, y=15"
+	sensorPattern = "Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d"
+)
 
+var (
 	// emptyInterval represents an empty interval
 	emptyInterval = interval{}
 )
@@ -41,10 +42,21 @@ type interval struct {
 	end   int
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    15,
+		Title:     "Beacon Exclusion Zone",
+		InputPath: "day15/day15.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
-	sensorsString := strings.Split(input, "\n")
-	sensors, err := parseSensors(sensorsString)
+	sensors, err := parse.Each(parse.Lines(input), parseSensor)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse sensors: %w", err)
 	}
@@ -69,70 +81,13 @@ func (d Day) SolvePartTwo() (string, error) {
 	return fmt.Sprintf("%d", distressBeacon.tuningFrequency()), nil
 }
 
-func parseSensors(sensorsString []string) ([]sensor, error) {
-	sensors := make([]sensor, 0, len(sensorsString))
-	for _, sensorString := range sensorsString {
-		sensor, err := parseSensor(sensorString)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse sensor: %w", err)
-		}
-		sensors = append(sensors, sensor)
-	}
-	return sensors, nil
-}
-
 func parseSensor(sensorString string) (sensor, error) {
-	matches := sensorRe.FindStringSubmatch(sensorString)
-	if len(matches) != 5 {
-		return sensor{}, fmt.Errorf("invalid sensor format: %s", sensorString)
-	}
-
-	position, err := parsePosition(matches[1], matches[2])
+	var s sensor
+	err := parse.Match(sensorString, sensorPattern, &s.position.x, &s.position.y, &s.closestBeacon.x, &s.closestBeacon.y)
 	if err != nil {
-		return sensor{}, fmt.Errorf("coud not parse sensor position: %w", err)
+		return sensor{}, fmt.Errorf("could not parse sensor: %w", err)
 	}
-
-	closestBeacon, err := parsePosition(matches[3], matches[4])
-	if err != nil {
-		return sensor{}, fmt.Errorf("coud not parse closest beacon position: %w", err)
-	}
-
-	return sensor{position: position, closestBeacon: closestBeacon}, nil
-}
-
-func parsePosition(xString, yString string) (position, error) {
-	x, err := parseSignedInt(xString)
-	if err != nil {
-		return position{}, fmt.Errorf("coud not parse x: %w", err)
-	}
-
-	y, err := parseSignedInt(yString)
-	if err != nil {
-		return position{}, fmt.Errorf("coud not parse y: %w", err)
-	}
-
-	return position{x: x, y: y}, nil
-}
-
-func parseSignedInt(s string) (int, error) {
-	if s == "" {
-		return 0, fmt.Errorf("empty integer")
-	}
-
-	if s[0] == '-' {
-		i, err := strconv.Atoi(s[1:])
-		if err != nil {
-			return 0, err
-		}
-		return -i, nil
-	}
-
-	i, err := strconv.Atoi(s)
-	if err != nil {
-		return 0, err
-	}
-
-	return i, nil
+	return s, nil
 }
 
 func (d Day) getDistressBeacon() (position, error) {
@@ -222,4 +177,4 @@ func (p position) manhattanDistance(position position) int {
 	minY := min(p.y, position.y)
 	maxY := max(p.y, position.y)
 	return maxX - minX + maxY - minY
-}
\ No newline at end of file
+}
@@ -1,12 +1,17 @@
 package day16
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"maps"
+	"math"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -14,6 +19,31 @@ type Day struct {
 	valves map[string]valve
 }
 
+const (
+	startValve = "AA"
+	// minutesAlone is the time before the volcano erupts when opening the valves alone
+	minutesAlone = 30
+	// minutesTeachingElephant is the time spent teaching the elephant before opening valves together
+	minutesTeachingElephant = 4
+)
+
+// agents holds the name of who opens the valves, the elephant only helps in part two
+var agents = []string{"you", "the elephant"}
+
+// Plan holds who opens each valve and when, and the total pressure released
+type Plan struct {
+	Pressure int
+	// Openings holds the valves opened sorted by minute
+	Openings []Opening
+}
+
+// Opening is a valve opened by an agent during the given minute, it releases pressure from the next minute
+type Opening struct {
+	Agent  string
+	Valve  string
+	Minute int
+}
+
 var (
 	// Regex matching a line of the form "Valve AA has flow rate=0; tunnels lead to valves DD, II, BB"
 	valveRe = regexp.MustCompile(`^Valve (.+) has flow rate=(\d+); tunnels? leads? to valves? (.+)$`)
@@ -25,6 +55,27 @@ type valve struct {
 	tunnels  []string
 }
 
+// network is the tunnel graph compressed to the valves that release pressure, the only ones worth walking to.
+// Sets of valves are bitmasks where bit i is the i-th valve.
+type network struct {
+	labels    []string
+	flowRates []int
+	// distances holds the minutes to walk between any two valves, the start valve is the last one
+	distances [][]int
+}
+
+func init() {
+	registry.Register(registry.Day{
+		Number:    16,
+		Title:     "Proboscidea Volcanium",
+		InputPath: "day16/day16.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	valvesString := strings.Split(input, "\n")
@@ -39,15 +90,80 @@ func NewDay(input string) (*Day, error) {
 
 // SolvePartOne solves part one
 func (d Day) SolvePartOne() (string, error) {
-	return "", nil
-	//opened := util.NewSet[string]()
-	//opened := "000000"
-	//return fmt.Sprintf("%d", d.rec2(30, d.valves["AA"], opened)), nil
+	return d.SolvePartOneContext(context.Background())
 }
 
 // SolvePartTwo solves part two
 func (d Day) SolvePartTwo() (string, error) {
-	return "", nil
+	return d.SolvePartTwoContext(context.Background())
+}
+
+// SolvePartOneContext solves part one, stopping the search when the context is done
+func (d Day) SolvePartOneContext(ctx context.Context) (string, error) {
+	plan, err := d.Plan(ctx, 1)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", plan.Pressure), nil
+}
+
+// SolvePartTwoContext solves part two, stopping the search when the context is done
+func (d Day) SolvePartTwoContext(ctx context.Context) (string, error) {
+	plan, err := d.Plan(ctx, 2)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", plan.Pressure), nil
+}
+
+// ExplainPartOneContext solves part one and returns the plan behind it, stopping the search when the context is done
+func (d Day) ExplainPartOneContext(ctx context.Context) (string, string, error) {
+	plan, err := d.Plan(ctx, 1)
+	if err != nil {
+		return "", "", err
+	}
+	return fmt.Sprintf("%d", plan.Pressure), plan.String(), nil
+}
+
+// ExplainPartTwoContext solves part two and returns the plan behind it, stopping the search when the context is done
+func (d Day) ExplainPartTwoContext(ctx context.Context) (string, string, error) {
+	plan, err := d.Plan(ctx, 2)
+	if err != nil {
+		return "", "", err
+	}
+	return fmt.Sprintf("%d", plan.Pressure), plan.String(), nil
+}
+
+// Plan returns the plan that releases the most pressure when opening the valves alone (part one)
+// or with the elephant (part two)
+func (d Day) Plan(ctx context.Context, numAgents int) (Plan, error) {
+	if numAgents < 1 || numAgents > len(agents) {
+		return Plan{}, fmt.Errorf("invalid number of agents %d, want 1 or 2", numAgents)
+	}
+
+	network, err := newNetwork(d.valves)
+	if err != nil {
+		return Plan{}, fmt.Errorf("could not build network: %w", err)
+	}
+
+	minutes := minutesAlone
+	if numAgents == 2 {
+		minutes -= minutesTeachingElephant
+	}
+
+	best, err := network.bestRoutes(ctx, minutes)
+	if err != nil {
+		return Plan{}, fmt.Errorf("could not find routes: %w", err)
+	}
+
+	var chosen []route
+	if numAgents == 1 {
+		chosen = []route{bestRoute(best)}
+	} else {
+		you, elephant := bestDisjointRoutes(best)
+		chosen = []route{you, elephant}
+	}
+	return network.plan(minutes, chosen), nil
 }
 
 func parseValves(valvesString []string) (map[string]valve, error) {
@@ -79,105 +195,179 @@ func parseValve(valveString string) (valve, error) {
 	}, nil
 }
 
-func (d Day) rec(remainingMinutes int, current valve, opened util.Set[string]) int {
-	if len(opened) == 6 {
-		return 0
-	}
-	if remainingMinutes == 0 {
-		return 0
+// newNetwork computes the distances between all the valves with Floyd-Warshall and keeps the ones with flow
+func newNetwork(valves map[string]valve) (network, error) {
+	if _, ok := valves[startValve]; !ok {
+		return network{}, fmt.Errorf("missing start valve %s", startValve)
 	}
 
-	// don't open valve and take tunnel
-	tmp2 := 0
-	for _, tunnel := range current.tunnels {
-		maxPressure := d.rec(remainingMinutes-1, d.valves[tunnel], opened)
-		tmp2 = max(tmp2, maxPressure)
+	labels := slices.Sorted(maps.Keys(valves))
+	index := make(map[string]int, len(labels))
+	for i, label := range labels {
+		index[label] = i
 	}
 
-	tmp := 0
-	// open valve
-	if current.flowRate > 0 && !opened.Contains(current.label) {
-		opened.Add(current.label)
-		for _, tunnel := range current.tunnels {
-			maxPressure := d.rec(remainingMinutes-1, d.valves[tunnel], opened)
-			tmp = max(tmp, maxPressure)
+	// Valves that can't reach each other are further apart than any time limit, half the max avoids overflowing sums
+	unreachable := math.MaxInt / 2
+	distances := make([][]int, len(labels))
+	for i, label := range labels {
+		distances[i] = make([]int, len(labels))
+		for j := range distances[i] {
+			distances[i][j] = unreachable
+		}
+		distances[i][i] = 0
+		for _, tunnel := range valves[label].tunnels {
+			j, ok := index[tunnel]
+			if !ok {
+				return network{}, fmt.Errorf("valve %s has a tunnel to unknown valve %s", label, tunnel)
+			}
+			distances[i][j] = 1
 		}
-		opened.Remove(current.label)
-		tmp += (remainingMinutes - 1) * current.flowRate
-	}
-
-	return max(tmp, tmp2)
-}
-
-func isOpened(opened, label string) bool {
-	if label == "BB" {
-		return opened[0] == '1'
 	}
-	if label == "CC" {
-		return opened[1] == '1'
+	for k := range labels {
+		for i := range labels {
+			for j := range labels {
+				distances[i][j] = min(distances[i][j], distances[i][k]+distances[k][j])
+			}
+		}
 	}
-	if label == "DD" {
-		return opened[2] == '1'
+
+	var useful []int
+	for i, label := range labels {
+		if valves[label].flowRate > 0 {
+			useful = append(useful, i)
+		}
 	}
-	if label == "EE" {
-		return opened[3] == '1'
+	if len(useful) > 64 {
+		return network{}, fmt.Errorf("%d valves with flow don't fit in a bitmask of 64", len(useful))
 	}
-	if label == "HH" {
-		return opened[4] == '1'
+
+	n := network{distances: make([][]int, len(useful)+1)}
+	for _, i := range useful {
+		n.labels = append(n.labels, labels[i])
+		n.flowRates = append(n.flowRates, valves[labels[i]].flowRate)
 	}
-	if label == "JJ" {
-		return opened[5] == '1'
+	kept := append(slices.Clone(useful), index[startValve])
+	for a, i := range kept {
+		n.distances[a] = make([]int, len(kept))
+		for b, j := range kept {
+			n.distances[a][b] = distances[i][j]
+		}
 	}
-	return false
+	return n, nil
 }
 
-func addToOpened(opened, label string) string {
-	if label == "BB" {
-		return "1" + opened[1:]
-	}
-	if label == "CC" {
-		return opened[:1] + "1" + opened[2:]
-	}
-	if label == "DD" {
-		return opened[:2] + "1" + opened[3:]
-	}
-	if label == "EE" {
-		return opened[:3] + "1" + opened[4:]
-	}
-	if label == "HH" {
-		return opened[:4] + "1" + opened[5:]
+// start returns the index of the start valve in the distances
+func (n network) start() int {
+	return len(n.flowRates)
+}
+
+// route is a sequence of valves opened by a single agent
+type route struct {
+	opened   uint64
+	pressure int
+	// valves holds the valves in the order they are opened, and minutes the minutes left after opening each of them
+	valves  []int
+	minutes []int
+}
+
+// bestRoutes returns, for each set of valves that can be opened in the given minutes, the route that opens them
+// releasing the most pressure
+func (n network) bestRoutes(ctx context.Context, minutes int) (map[uint64]route, error) {
+	done := ctx.Done()
+	best := make(map[uint64]route)
+
+	var valves, left []int
+	var explore func(current, minutes int, opened uint64, pressure int) error
+	explore = func(current, minutes int, opened uint64, pressure int) error {
+		select {
+		case <-done:
+			return ctx.Err()
+		default:
+		}
+
+		if known, ok := best[opened]; !ok || pressure > known.pressure {
+			best[opened] = route{opened: opened, pressure: pressure, valves: slices.Clone(valves), minutes: slices.Clone(left)}
+		}
+
+		for next, flowRate := range n.flowRates {
+			if opened&(1<<next) != 0 {
+				continue
+			}
+			// Walking to the valve and opening it takes a minute more than the distance
+			remaining := minutes - n.distances[current][next] - 1
+			if remaining <= 0 {
+				continue
+			}
+
+			valves, left = append(valves, next), append(left, remaining)
+			err := explore(next, remaining, opened|1<<next, pressure+remaining*flowRate)
+			valves, left = valves[:len(valves)-1], left[:len(left)-1]
+			if err != nil {
+				return err
+			}
+		}
+		return nil
 	}
-	if label == "JJ" {
-		return opened[:5] + "1"
+
+	if err := explore(n.start(), minutes, 0, 0); err != nil {
+		return nil, err
 	}
-	return ""
+	return best, nil
 }
 
-func (d Day) rec2(remainingMinutes int, current valve, opened string) int {
-	if opened == "111111" {
-		return 0
-	}
-	if remainingMinutes == 0 {
-		return 0
+// bestRoute returns the route that releases the most pressure, there is always the one that opens no valve
+func bestRoute(best map[uint64]route) route {
+	var result route
+	for _, r := range best {
+		if r.pressure > result.pressure {
+			result = r
+		}
 	}
+	return result
+}
 
-	// don't open valve and take tunnel
-	tmp2 := 0
-	for _, tunnel := range current.tunnels {
-		maxPressure := d.rec2(remainingMinutes-1, d.valves[tunnel], opened)
-		tmp2 = max(tmp2, maxPressure)
+// bestDisjointRoutes returns the two routes that release the most pressure together without opening the same valve
+func bestDisjointRoutes(best map[uint64]route) (route, route) {
+	routes := slices.SortedFunc(maps.Values(best), func(a, b route) int { return cmp.Compare(b.pressure, a.pressure) })
+
+	var first, second route
+	for i, a := range routes {
+		// Routes are sorted by pressure, so no later pair can beat the best one found so far
+		if 2*a.pressure <= first.pressure+second.pressure {
+			break
+		}
+		for _, b := range routes[i:] {
+			if a.pressure+b.pressure <= first.pressure+second.pressure {
+				break
+			}
+			if a.opened&b.opened == 0 {
+				first, second = a, b
+			}
+		}
 	}
+	return first, second
+}
 
-	tmp := 0
-	// open valve
-	if current.flowRate > 0 && !isOpened(opened, current.label) {
-		opened = addToOpened(opened, current.label)
-		for _, tunnel := range current.tunnels {
-			maxPressure := d.rec2(remainingMinutes-1, d.valves[tunnel], opened)
-			tmp = max(tmp, maxPressure)
+// plan returns the plan of the agents following the given routes
+func (n network) plan(minutes int, routes []route) Plan {
+	var plan Plan
+	for agent, r := range routes {
+		plan.Pressure += r.pressure
+		for i, valve := range r.valves {
+			plan.Openings = append(plan.Openings, Opening{Agent: agents[agent], Valve: n.labels[valve], Minute: minutes - r.minutes[i]})
 		}
-		tmp += (remainingMinutes - 1) * current.flowRate
 	}
+	slices.SortStableFunc(plan.Openings, func(a, b Opening) int { return cmp.Compare(a.Minute, b.Minute) })
+	return plan
+}
 
-	return max(tmp, tmp2)
+// String returns the plan with an opening per line
+func (p Plan) String() string {
+	var b strings.Builder
+	for _, o := range p.Openings {
+		fmt.Fprintf(&b, "Minute %d: valve %s opened by %s\n", o.Minute, o.Valve, o.Agent)
+	}
+	fmt.Fprintf(&b, "Total pressure released: %d", p.Pressure)
+	return b.String()
 }
@@ -1,6 +1,8 @@
 package day16
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -57,14 +59,192 @@ func TestSolvePartOne(t *testing.T) {
 	answer, err := day.SolvePartOne()
 	require.NoError(t, err)
 
-	assert.Equal(t, "", answer)
+	assert.Equal(t, "1651", answer)
 }
 
 func TestSolvePartTwo(t *testing.T) {
-	day := &Day{}
+	day := &Day{valves: exampleValves()}
 
 	answer, err := day.SolvePartTwo()
 	require.NoError(t, err)
 
-	assert.Equal(t, "", answer)
+	assert.Equal(t, "1707", answer)
+}
+
+func exampleValves() map[string]valve {
+	return map[string]valve{
+		"AA": {label: "AA", flowRate: 0, tunnels: []string{"DD", "II", "BB"}},
+		"BB": {label: "BB", flowRate: 13, tunnels: []string{"CC", "AA"}},
+		"CC": {label: "CC", flowRate: 2, tunnels: []string{"DD", "BB"}},
+		"DD": {label: "DD", flowRate: 20, tunnels: []string{"CC", "AA", "EE"}},
+		"EE": {label: "EE", flowRate: 3, tunnels: []string{"FF", "DD"}},
+		"FF": {label: "FF", flowRate: 0, tunnels: []string{"EE", "GG"}},
+		"GG": {label: "GG", flowRate: 0, tunnels: []string{"FF", "HH"}},
+		"HH": {label: "HH", flowRate: 22, tunnels: []string{"GG"}},
+		"II": {label: "II", flowRate: 0, tunnels: []string{"AA", "JJ"}},
+		"JJ": {label: "JJ", flowRate: 21, tunnels: []string{"II"}},
+	}
+}
+
+func TestNewNetworkShould(t *testing.T) {
+	t.Run("keep only the valves with flow", func(t *testing.T) {
+		n, err := newNetwork(exampleValves())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"BB", "CC", "DD", "EE", "HH", "JJ"}, n.labels)
+		assert.Equal(t, []int{13, 2, 20, 3, 22, 21}, n.flowRates)
+	})
+
+	t.Run("compute the distances through the removed valves", func(t *testing.T) {
+		n, err := newNetwork(exampleValves())
+		require.NoError(t, err)
+
+		// AA -> DD -> EE -> FF -> GG -> HH
+		assert.Equal(t, 5, n.distances[n.start()][4])
+		// JJ -> II -> AA -> BB
+		assert.Equal(t, 3, n.distances[5][0])
+		assert.Equal(t, 0, n.distances[2][2])
+	})
+
+	t.Run("fail with tunnels to unknown valves", func(t *testing.T) {
+		valves := exampleValves()
+		valves["ZZ"] = valve{label: "ZZ", flowRate: 1, tunnels: []string{"YY"}}
+
+		_, err := newNetwork(valves)
+		assert.EqualError(t, err, "valve ZZ has a tunnel to unknown valve YY")
+	})
+
+	t.Run("fail without start valve", func(t *testing.T) {
+		valves := exampleValves()
+		delete(valves, "AA")
+
+		_, err := newNetwork(valves)
+		assert.EqualError(t, err, "missing start valve AA")
+	})
+}
+
+func TestBestRoutesShould(t *testing.T) {
+	t.Run("work with any labels", func(t *testing.T) {
+		valves := map[string]valve{
+			"AA": {label: "AA", flowRate: 0, tunnels: []string{"XY"}},
+			"XY": {label: "XY", flowRate: 10, tunnels: []string{"AA", "QR"}},
+			"QR": {label: "QR", flowRate: 1, tunnels: []string{"XY"}},
+		}
+		n, err := newNetwork(valves)
+		require.NoError(t, err)
+
+		best, err := n.bestRoutes(context.Background(), 5)
+		require.NoError(t, err)
+
+		// Open XY at minute 2 and QR at minute 4
+		assert.Equal(t, 3*10+1*1, bestRoute(best).pressure)
+		// Opening only QR takes until minute 3
+		assert.Equal(t, 2*1, best[0b01].pressure)
+	})
+
+	t.Run("ignore valves that can't be reached", func(t *testing.T) {
+		valves := map[string]valve{
+			"AA": {label: "AA", flowRate: 5, tunnels: nil},
+			"BB": {label: "BB", flowRate: 10, tunnels: nil},
+		}
+		n, err := newNetwork(valves)
+		require.NoError(t, err)
+
+		best, err := n.bestRoutes(context.Background(), 30)
+		require.NoError(t, err)
+
+		assert.Equal(t, 29*5, bestRoute(best).pressure)
+	})
+}
+
+func TestBestDisjointRoutesShould(t *testing.T) {
+	t.Run("not open the same valve twice", func(t *testing.T) {
+		best := map[uint64]route{
+			0b000: {opened: 0b000, pressure: 0},
+			0b011: {opened: 0b011, pressure: 100},
+			0b110: {opened: 0b110, pressure: 90},
+			0b100: {opened: 0b100, pressure: 30},
+			0b001: {opened: 0b001, pressure: 50},
+		}
+
+		first, second := bestDisjointRoutes(best)
+
+		assert.Equal(t, 140, first.pressure+second.pressure)
+		assert.Zero(t, first.opened&second.opened)
+	})
+}
+
+func TestPlanShould(t *testing.T) {
+	day := &Day{valves: exampleValves()}
+
+	t.Run("report when each valve is opened alone", func(t *testing.T) {
+		plan, err := day.Plan(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, Plan{
+			Pressure: 1651,
+			Openings: []Opening{
+				{Agent: "you", Valve: "DD", Minute: 2},
+				{Agent: "you", Valve: "BB", Minute: 5},
+				{Agent: "you", Valve: "JJ", Minute: 9},
+				{Agent: "you", Valve: "HH", Minute: 17},
+				{Agent: "you", Valve: "EE", Minute: 21},
+				{Agent: "you", Valve: "CC", Minute: 24},
+			},
+		}, plan)
+	})
+
+	t.Run("report who opens each valve with the elephant", func(t *testing.T) {
+		plan, err := day.Plan(context.Background(), 2)
+		require.NoError(t, err)
+
+		// Both agents can swap their routes, so only check which valves are opened together
+		routes := make(map[string][]Opening)
+		for _, o := range plan.Openings {
+			routes[o.Agent] = append(routes[o.Agent], Opening{Valve: o.Valve, Minute: o.Minute})
+		}
+
+		assert.Equal(t, 1707, plan.Pressure)
+		assert.ElementsMatch(t, [][]Opening{
+			{{Valve: "JJ", Minute: 3}, {Valve: "BB", Minute: 7}, {Valve: "CC", Minute: 9}},
+			{{Valve: "DD", Minute: 2}, {Valve: "HH", Minute: 7}, {Valve: "EE", Minute: 11}},
+		}, []([]Opening){routes["you"], routes["the elephant"]})
+	})
+
+	t.Run("render an opening per line", func(t *testing.T) {
+		plan := Plan{
+			Pressure: 20,
+			Openings: []Opening{{Agent: "you", Valve: "DD", Minute: 2}, {Agent: "the elephant", Valve: "BB", Minute: 3}},
+		}
+
+		assert.Equal(t, "Minute 2: valve DD opened by you\nMinute 3: valve BB opened by the elephant\nTotal pressure released: 20", plan.String())
+	})
+
+	t.Run("explain the answers with the plan", func(t *testing.T) {
+		answer, explanation, err := day.ExplainPartOneContext(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "1651", answer)
+		assert.True(t, strings.HasPrefix(explanation, "Minute 2: valve DD opened by you\n"))
+		assert.True(t, strings.HasSuffix(explanation, "\nTotal pressure released: 1651"))
+	})
+
+	t.Run("fail with more agents than the elephant", func(t *testing.T) {
+		_, err := day.Plan(context.Background(), 3)
+		assert.Error(t, err)
+	})
+}
+
+func TestSolveContextShould(t *testing.T) {
+	t.Run("stop when the context is done", func(t *testing.T) {
+		day := &Day{valves: exampleValves()}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := day.SolvePartOneContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = day.SolvePartTwoContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
 }
@@ -1,8 +1,13 @@
 package day17
 
 import (
-	"errors"
+	"encoding/binary"
 	"fmt"
+	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -11,409 +16,330 @@ type Day struct {
 }
 
 const (
-	chamberWidth  = 7
-	chamberHeight = 100_000
+	chamberWidth = 7
 
-	air  rune = '.'
-	rock rune = '#'
-	wall rune = '|'
-)
+	// rocks appear this far from the left wall and this many rows above the highest rock
+	appearX      = 2
+	appearHeight = 3
+
+	// maxChamberWidth is the number of columns that fit in the bitmask of a row
+	maxChamberWidth = 64
 
-var (
-	errRockLanded = errors.New("rock landed")
+	partOneRocks = 2022
+	partTwoRocks = 1_000_000_000_000
 )
 
-type shape interface {
-	appear(int, map[position]rune) position
-	fallDown(position, map[position]rune) (position, error)
-	pushRight(position, map[position]rune) position
-	pushLeft(position, map[position]rune) position
+// shapesArt holds the shapes of the rocks of the puzzle in the order they fall
+var shapesArt = []string{
+	`####`,
+	`.#.
+###
+.#.`,
+	`..#
+..#
+###`,
+	`#
+#
+#
+#`,
+	`##
+##`,
 }
 
-type position struct {
-	x, y int
+// Shape is a rock that falls in the chamber
+type Shape struct {
+	// cells holds the offset of each cell of the rock from its bottom left corner, Y grows upwards
+	cells []util.Point2
+	width int
 }
 
-// NewDay returns a new Day that solves part one and two for the given input
-func NewDay(input string) (*Day, error) {
-	return &Day{
-		jetPattern: input,
-	}, nil
-}
+// ParseShape returns the shape drawn with '#' for rock and '.' for air, from the top row to the bottom one
+func ParseShape(art string) (Shape, error) {
+	grid, err := parse.Grid(parse.Lines(art), func(r rune) (bool, error) {
+		switch r {
+		case '#':
+			return true, nil
+		case '.':
+			return false, nil
+		default:
+			return false, fmt.Errorf("invalid cell %q", r)
+		}
+	})
+	if err != nil {
+		return Shape{}, fmt.Errorf("invalid shape: %w", err)
+	}
 
-// SolvePartOne solves part one
-func (d Day) SolvePartOne() (string, error) {
-	const totalRocks = 2022
-
-	chamber := createChamber()
-	shapes := []shape{shape1{}, shape2{}, shape3{}, shape4{}, shape5{}}
-	yMax := -1
-	i := 0
-	for rock := 0; rock < totalRocks; rock++ {
-		s := shapes[rock%5]
-		rockPosition := s.appear(yMax, chamber)
-		for {
-			if d.jetPattern[i%len(d.jetPattern)] == '>' {
-				rockPosition = s.pushRight(rockPosition, chamber)
-			} else {
-				rockPosition = s.pushLeft(rockPosition, chamber)
-			}
-			i++
-			landing, err := s.fallDown(rockPosition, chamber)
-			if errors.Is(err, errRockLanded) {
-				yMax = max(yMax, landing.y)
-				break
-			}
-			rockPosition = landing
+	var shape Shape
+	for p, isRock := range grid.All() {
+		if isRock {
+			shape.cells = append(shape.cells, util.Point2{X: p.X, Y: grid.Rows() - 1 - p.Y})
+			shape.width = max(shape.width, p.X+1)
 		}
 	}
-	// we must add 1 to yMax because we use 0-based coordinates
-	return fmt.Sprintf("%d", yMax+1), nil
+	if len(shape.cells) == 0 {
+		return Shape{}, fmt.Errorf("invalid shape: it has no rock")
+	}
+	return shape, nil
 }
 
-// SolvePartTwo solves part two
-func (d Day) SolvePartTwo() (string, error) {
-	// TODO: Figure out how to find a recurring pattern in the layout to compute arbitrarily big total rocks.
-	return "", nil
+// Chamber describes where the rocks fall
+type Chamber struct {
+	Width int
+	// Shapes holds the shapes of the rocks in the order they fall, repeating once all of them have fallen
+	Shapes []Shape
 }
 
-func createChamber() map[position]rune {
-	chamber := make(map[position]rune)
-
-	for x := -1; x < chamberWidth; x++ {
-		chamber[position{x: x, y: -1}] = wall
-	}
-	for y := -1; y <= chamberHeight; y++ {
-		chamber[position{x: -1, y: y}] = wall
-		chamber[position{x: chamberWidth, y: y}] = wall
-	}
-	for x := 0; x < chamberWidth; x++ {
-		for y := 0; y < chamberHeight; y++ {
-			chamber[position{x: x, y: y}] = air
+// DefaultChamber returns the chamber of the puzzle
+func DefaultChamber() Chamber {
+	chamber := Chamber{Width: chamberWidth}
+	for _, art := range shapesArt {
+		shape, err := ParseShape(art)
+		if err != nil {
+			panic(err)
 		}
+		chamber.Shapes = append(chamber.Shapes, shape)
 	}
 	return chamber
 }
 
-// Represents shape with form:
-// ####
-type shape1 struct{}
-
-func (s shape1) appear(yMax int, chamber map[position]rune) position {
-	p := position{x: 2, y: yMax + 4}
-	chamber[position{x: p.x, y: p.y}] = rock
-	chamber[position{x: p.x + 1, y: p.y}] = rock
-	chamber[position{x: p.x + 2, y: p.y}] = rock
-	chamber[position{x: p.x + 3, y: p.y}] = rock
-	return p
-}
-
-func (s shape1) fallDown(p position, chamber map[position]rune) (position, error) {
-	down1 := position{x: p.x, y: p.y - 1}
-	down2 := position{x: p.x + 1, y: p.y - 1}
-	down3 := position{x: p.x + 2, y: p.y - 1}
-	down4 := position{x: p.x + 3, y: p.y - 1}
-	if chamber[down1] == air && chamber[down2] == air && chamber[down3] == air && chamber[down4] == air {
-		chamber[position{x: p.x, y: p.y}] = air
-		chamber[position{x: p.x + 1, y: p.y}] = air
-		chamber[position{x: p.x + 2, y: p.y}] = air
-		chamber[position{x: p.x + 3, y: p.y}] = air
-
-		chamber[down1] = rock
-		chamber[down2] = rock
-		chamber[down3] = rock
-		chamber[down4] = rock
-
-		return position{x: p.x, y: p.y - 1}, nil
+func (c Chamber) validate() error {
+	if c.Width < 1 || c.Width > maxChamberWidth {
+		return fmt.Errorf("chamber width %d must be between 1 and %d", c.Width, maxChamberWidth)
 	}
-	return p, errRockLanded
-}
-
-func (s shape1) pushRight(p position, chamber map[position]rune) position {
-	right1 := position{x: p.x + 4, y: p.y}
-	if chamber[right1] == air {
-		chamber[position{x: p.x, y: p.y}] = air
-		chamber[right1] = rock
-		return position{x: p.x + 1, y: p.y}
+	if len(c.Shapes) == 0 {
+		return fmt.Errorf("chamber has no shapes")
 	}
-	return p
-}
-
-func (s shape1) pushLeft(p position, chamber map[position]rune) position {
-	left1 := position{x: p.x - 1, y: p.y}
-	if chamber[left1] == air {
-		chamber[position{x: p.x + 3, y: p.y}] = air
-		chamber[left1] = rock
-		return position{x: p.x - 1, y: p.y}
+	for i, shape := range c.Shapes {
+		if len(shape.cells) == 0 {
+			return fmt.Errorf("shape %d has no rock", i+1)
+		}
+		if appearX+shape.width > c.Width {
+			return fmt.Errorf("shape %d of width %d doesn't fit in a chamber of width %d", i+1, shape.width, c.Width)
+		}
 	}
-	return p
+	return nil
 }
 
-// Represents shape with form:
-// .#.
-// ###
-// .#.
-type shape2 struct{}
-
-func (s shape2) appear(yMax int, chamber map[position]rune) position {
-	p := position{x: 2, y: yMax + 6}
-	chamber[position{x: p.x + 1, y: p.y}] = rock
-	chamber[position{x: p.x, y: p.y - 1}] = rock
-	chamber[position{x: p.x + 1, y: p.y - 1}] = rock
-	chamber[position{x: p.x + 2, y: p.y - 1}] = rock
-	chamber[position{x: p.x + 1, y: p.y - 2}] = rock
-	return p
+func init() {
+	registry.Register(registry.Day{
+		Number:    17,
+		Title:     "Pyroclastic Flow",
+		InputPath: "day17/day17.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
 }
 
-func (s shape2) fallDown(p position, chamber map[position]rune) (position, error) {
-	down1 := position{x: p.x, y: p.y - 2}
-	down2 := position{x: p.x + 1, y: p.y - 3}
-	down3 := position{x: p.x + 2, y: p.y - 2}
-
-	if chamber[down1] == air && chamber[down2] == air && chamber[down3] == air {
-		chamber[position{x: p.x, y: p.y - 1}] = air
-		chamber[position{x: p.x + 1, y: p.y}] = air
-		chamber[position{x: p.x + 2, y: p.y - 1}] = air
+// NewDay returns a new Day that solves part one and two for the given input
+func NewDay(input string) (*Day, error) {
+	if input == "" {
+		return nil, fmt.Errorf("empty jet pattern")
+	}
+	for i, jet := range input {
+		if jet != '<' && jet != '>' {
+			return nil, fmt.Errorf("invalid jet %q at position %d", jet, i)
+		}
+	}
 
-		chamber[down1] = rock
-		chamber[down2] = rock
-		chamber[down3] = rock
+	return &Day{
+		jetPattern: input,
+	}, nil
+}
 
-		return position{x: p.x, y: p.y - 1}, nil
+// SolvePartOne solves part one
+func (d Day) SolvePartOne() (string, error) {
+	height, err := d.TowerHeight(DefaultChamber(), partOneRocks)
+	if err != nil {
+		return "", err
 	}
-	return p, errRockLanded
+	return fmt.Sprintf("%d", height), nil
 }
 
-func (s shape2) pushRight(p position, chamber map[position]rune) position {
-	right1 := position{x: p.x + 2, y: p.y}
-	right2 := position{x: p.x + 3, y: p.y - 1}
-	right3 := position{x: p.x + 2, y: p.y - 2}
-
-	if chamber[right1] == air && chamber[right2] == air && chamber[right3] == air {
-		chamber[position{x: p.x + 1, y: p.y}] = air
-		chamber[position{x: p.x, y: p.y - 1}] = air
-		chamber[position{x: p.x + 1, y: p.y - 2}] = air
-
-		chamber[right1] = rock
-		chamber[right2] = rock
-		chamber[right3] = rock
-
-		return position{x: p.x + 1, y: p.y}
+// SolvePartTwo solves part two
+func (d Day) SolvePartTwo() (string, error) {
+	height, err := d.TowerHeight(DefaultChamber(), partTwoRocks)
+	if err != nil {
+		return "", err
 	}
-	return p
+	return fmt.Sprintf("%d", height), nil
 }
 
-func (s shape2) pushLeft(p position, chamber map[position]rune) position {
-	left1 := position{x: p.x, y: p.y}
-	left2 := position{x: p.x - 1, y: p.y - 1}
-	left3 := position{x: p.x, y: p.y - 2}
-
-	if chamber[left1] == air && chamber[left2] == air && chamber[left3] == air {
-		chamber[position{x: p.x + 1, y: p.y}] = air
-		chamber[position{x: p.x + 2, y: p.y - 1}] = air
-		chamber[position{x: p.x + 1, y: p.y - 2}] = air
-
-		chamber[left1] = rock
-		chamber[left2] = rock
-		chamber[left3] = rock
+// TowerHeight returns the height of the tower after the given number of rocks have fallen in the chamber.
+// Once the next shape, the next jet and the surface of the tower repeat, the tower grows by the same height for the
+// same number of rocks forever, so the height is extrapolated instead of simulating all the rocks.
+func (d Day) TowerHeight(chamber Chamber, totalRocks int) (int, error) {
+	if err := chamber.validate(); err != nil {
+		return 0, err
+	}
 
-		return position{x: p.x - 1, y: p.y}
+	sim := newSimulation(d.jetPattern, chamber)
+	detector := util.NewCycleDetector[simulationState]()
+	heights := []int{0}
+	for sim.rocks < totalRocks {
+		if cycle, ok := detector.Observe(sim.rocks, sim.state()); ok {
+			return cycle.Extrapolate(totalRocks, func(rocks int) int { return heights[rocks] }), nil
+		}
+		sim.dropRock()
+		heights = append(heights, sim.height())
 	}
-	return p
+	return sim.height(), nil
 }
 
-// Represents shape of with form:
-// ..#
-// ..#
-// ###
-type shape3 struct{}
-
-func (s shape3) appear(yMax int, chamber map[position]rune) position {
-	p := position{x: 2, y: yMax + 6}
-	chamber[position{x: p.x + 2, y: p.y}] = rock
-	chamber[position{x: p.x + 2, y: p.y - 1}] = rock
-	chamber[position{x: p.x, y: p.y - 2}] = rock
-	chamber[position{x: p.x + 1, y: p.y - 2}] = rock
-	chamber[position{x: p.x + 2, y: p.y - 2}] = rock
-	return p
+// simulation drops rocks in the chamber one at a time
+type simulation struct {
+	jetPattern string
+	chamber    Chamber
+	// rows holds a bitmask per row of the tower from the floor up, where bit X is set if column X has rock
+	rows []uint64
+	// rocks holds the number of rocks that have fallen
+	rocks int
+	// jet holds the index of the next jet in the pattern
+	jet int
 }
 
-func (s shape3) fallDown(p position, chamber map[position]rune) (position, error) {
-	down1 := position{x: p.x, y: p.y - 3}
-	down2 := position{x: p.x + 1, y: p.y - 3}
-	down3 := position{x: p.x + 2, y: p.y - 3}
-
-	if chamber[down1] == air && chamber[down2] == air && chamber[down3] == air {
-		chamber[position{x: p.x + 2, y: p.y}] = air
-		chamber[position{x: p.x, y: p.y - 2}] = air
-		chamber[position{x: p.x + 1, y: p.y - 2}] = air
-
-		chamber[down1] = rock
-		chamber[down2] = rock
-		chamber[down3] = rock
-
-		return position{x: p.x, y: p.y - 1}, nil
-	}
-	return p, errRockLanded
+// simulationState holds everything that determines how the tower grows from now on
+type simulationState struct {
+	shape int
+	jet   int
+	// surface holds the air cells that falling rocks can reach, see simulation.surface
+	surface string
 }
 
-func (s shape3) pushRight(p position, chamber map[position]rune) position {
-	right1 := position{x: p.x + 3, y: p.y}
-	right2 := position{x: p.x + 3, y: p.y - 1}
-	right3 := position{x: p.x + 3, y: p.y - 2}
-
-	if chamber[right1] == air && chamber[right2] == air && chamber[right3] == air {
-		chamber[position{x: p.x + 2, y: p.y}] = air
-		chamber[position{x: p.x + 2, y: p.y - 1}] = air
-		chamber[position{x: p.x, y: p.y - 2}] = air
-
-		chamber[right1] = rock
-		chamber[right2] = rock
-		chamber[right3] = rock
-
-		return position{x: p.x + 1, y: p.y}
-	}
-	return p
+func newSimulation(jetPattern string, chamber Chamber) *simulation {
+	return &simulation{jetPattern: jetPattern, chamber: chamber}
 }
 
-func (s shape3) pushLeft(p position, chamber map[position]rune) position {
-	left1 := position{x: p.x + 1, y: p.y}
-	left2 := position{x: p.x + 1, y: p.y - 1}
-	left3 := position{x: p.x - 1, y: p.y - 2}
-
-	if chamber[left1] == air && chamber[left2] == air && chamber[left3] == air {
-		chamber[position{x: p.x + 2, y: p.y}] = air
-		chamber[position{x: p.x + 2, y: p.y - 1}] = air
-		chamber[position{x: p.x + 2, y: p.y - 2}] = air
-
-		chamber[left1] = rock
-		chamber[left2] = rock
-		chamber[left3] = rock
-
-		return position{x: p.x - 1, y: p.y}
-	}
-	return p
+// height returns the height of the tower
+func (s *simulation) height() int {
+	return len(s.rows)
 }
 
-// Represents shape with form:
-// #
-// #
-// #
-// #
-type shape4 struct{}
-
-func (s shape4) appear(yMax int, chamber map[position]rune) position {
-	p := position{x: 2, y: yMax + 7}
-	chamber[position{x: p.x, y: p.y}] = rock
-	chamber[position{x: p.x, y: p.y - 1}] = rock
-	chamber[position{x: p.x, y: p.y - 2}] = rock
-	chamber[position{x: p.x, y: p.y - 3}] = rock
-	return p
+func (s *simulation) state() simulationState {
+	return simulationState{shape: s.rocks % len(s.chamber.Shapes), jet: s.jet, surface: s.surface()}
 }
 
-func (s shape4) fallDown(p position, chamber map[position]rune) (position, error) {
-	down1 := position{x: p.x, y: p.y - 4}
-	if chamber[down1] == air {
-		chamber[position{x: p.x, y: p.y}] = air
-
-		chamber[down1] = rock
-
-		return position{x: p.x, y: p.y - 1}, nil
+// surface returns the air cells reachable from above the tower, as the bitmask of each row from the top down
+// encoded in 8 bytes. Rocks never reach the cells below, so towers with the same surface grow in the same way.
+func (s *simulation) surface() string {
+	full := uint64(1)<<s.chamber.Width - 1
+	air := func(depth int) uint64 {
+		if depth == 0 {
+			return full
+		}
+		return ^s.rows[s.height()-depth] & full
 	}
-	return p, errRockLanded
-}
 
-func (s shape4) pushRight(p position, chamber map[position]rune) position {
-	right1 := position{x: p.x + 1, y: p.y}
-	right2 := position{x: p.x + 1, y: p.y - 1}
-	right3 := position{x: p.x + 1, y: p.y - 2}
-	right4 := position{x: p.x + 1, y: p.y - 3}
-	if chamber[right1] == air && chamber[right2] == air && chamber[right3] == air && chamber[right4] == air {
-		chamber[position{x: p.x, y: p.y}] = air
-		chamber[position{x: p.x, y: p.y - 1}] = air
-		chamber[position{x: p.x, y: p.y - 2}] = air
-		chamber[position{x: p.x, y: p.y - 3}] = air
-
-		chamber[right1] = rock
-		chamber[right2] = rock
-		chamber[right3] = rock
-		chamber[right4] = rock
-		return position{x: p.x + 1, y: p.y}
+	// reachable holds a bitmask per row from the top down, the first row is the one above the tower.
+	// Cells can be reached from the rows above and below, so rows are filled again until nothing changes.
+	reachable := []uint64{full}
+	for changed := true; changed; {
+		changed = false
+		for depth := 1; depth <= s.height(); depth++ {
+			var current, below uint64
+			if depth < len(reachable) {
+				current = reachable[depth]
+			}
+			if depth+1 < len(reachable) {
+				below = reachable[depth+1]
+			}
+			next := spread(current|(reachable[depth-1]|below)&air(depth), air(depth))
+			if next == 0 {
+				break
+			}
+			if next != current {
+				changed = true
+			}
+			if depth == len(reachable) {
+				reachable = append(reachable, next)
+			} else {
+				reachable[depth] = next
+			}
+		}
 	}
-	return p
-}
 
-func (s shape4) pushLeft(p position, chamber map[position]rune) position {
-	left1 := position{x: p.x - 1, y: p.y}
-	left2 := position{x: p.x - 1, y: p.y - 1}
-	left3 := position{x: p.x - 1, y: p.y - 2}
-	left4 := position{x: p.x - 1, y: p.y - 3}
-	if chamber[left1] == air && chamber[left2] == air && chamber[left3] == air && chamber[left4] == air {
-		chamber[position{x: p.x, y: p.y}] = air
-		chamber[position{x: p.x, y: p.y - 1}] = air
-		chamber[position{x: p.x, y: p.y - 2}] = air
-		chamber[position{x: p.x, y: p.y - 3}] = air
-
-		chamber[left1] = rock
-		chamber[left2] = rock
-		chamber[left3] = rock
-		chamber[left4] = rock
-		return position{x: p.x - 1, y: p.y}
+	surface := make([]byte, 0, 8*len(reachable))
+	for _, row := range reachable {
+		surface = binary.LittleEndian.AppendUint64(surface, row)
 	}
-	return p
+	return string(surface)
 }
 
-// Represents shape with form:
-// ##
-// ##
-type shape5 struct{}
-
-func (s shape5) appear(yMax int, chamber map[position]rune) position {
-	p := position{x: 2, y: yMax + 5}
-	chamber[position{x: p.x, y: p.y}] = rock
-	chamber[position{x: p.x + 1, y: p.y}] = rock
-	chamber[position{x: p.x, y: p.y - 1}] = rock
-	chamber[position{x: p.x + 1, y: p.y - 1}] = rock
-	return p
+// spread returns the cells of the row reachable from the given ones moving left and right through air
+func spread(cells, air uint64) uint64 {
+	for {
+		next := (cells | cells<<1 | cells>>1) & air
+		if next == cells {
+			return cells
+		}
+		cells = next
+	}
 }
 
-func (s shape5) fallDown(p position, chamber map[position]rune) (position, error) {
-	down1 := position{x: p.x, y: p.y - 2}
-	down2 := position{x: p.x + 1, y: p.y - 2}
-	if chamber[down1] == air && chamber[down2] == air {
-		chamber[position{x: p.x, y: p.y}] = air
-		chamber[position{x: p.x + 1, y: p.y}] = air
+// dropRock drops the next rock until it lands
+func (s *simulation) dropRock() {
+	shape := s.chamber.Shapes[s.rocks%len(s.chamber.Shapes)]
+	p := util.Point2{X: appearX, Y: s.height() + appearHeight}
+	for {
+		push := util.Point2{X: 1}
+		if s.jetPattern[s.jet] == '<' {
+			push = util.Point2{X: -1}
+		}
+		s.jet = (s.jet + 1) % len(s.jetPattern)
+		if s.fits(shape, p.Add(push)) {
+			p = p.Add(push)
+		}
 
-		chamber[down1] = rock
-		chamber[down2] = rock
+		down := p.Add(util.Point2{Y: -1})
+		if !s.fits(shape, down) {
+			s.land(shape, p)
+			return
+		}
+		p = down
+	}
+}
 
-		return position{x: p.x, y: p.y - 1}, nil
+// fits returns true if the shape with its bottom left corner at the given position only covers air of the chamber
+func (s *simulation) fits(shape Shape, p util.Point2) bool {
+	for _, cell := range shape.cells {
+		c := p.Add(cell)
+		if c.X < 0 || c.X >= s.chamber.Width || c.Y < 0 {
+			return false
+		}
+		if c.Y < len(s.rows) && s.rows[c.Y]&(1<<c.X) != 0 {
+			return false
+		}
 	}
-	return p, errRockLanded
+	return true
 }
 
-func (s shape5) pushRight(p position, chamber map[position]rune) position {
-	right1 := position{x: p.x + 2, y: p.y}
-	right2 := position{x: p.x + 2, y: p.y - 1}
-	if chamber[right1] == air && chamber[right2] == air {
-		chamber[position{x: p.x, y: p.y}] = air
-		chamber[position{x: p.x, y: p.y - 1}] = air
-		chamber[right1] = rock
-		chamber[right2] = rock
-		return position{x: p.x + 1, y: p.y}
+// land adds the rock of the shape with its bottom left corner at the given position to the tower
+func (s *simulation) land(shape Shape, p util.Point2) {
+	s.rocks++
+	for _, cell := range shape.cells {
+		c := p.Add(cell)
+		for len(s.rows) <= c.Y {
+			s.rows = append(s.rows, 0)
+		}
+		s.rows[c.Y] |= 1 << c.X
 	}
-	return p
 }
 
-func (s shape5) pushLeft(p position, chamber map[position]rune) position {
-	left1 := position{x: p.x - 1, y: p.y}
-	left2 := position{x: p.x - 1, y: p.y - 1}
-	if chamber[left1] == air && chamber[left2] == air {
-		chamber[position{x: p.x + 1, y: p.y}] = air
-		chamber[position{x: p.x + 1, y: p.y - 1}] = air
-		chamber[left1] = rock
-		chamber[left2] = rock
-		return position{x: p.x - 1, y: p.y}
+// String returns the tower drawn from the top row to the floor, like the puzzle does
+func (s *simulation) String() string {
+	var b strings.Builder
+	for y := len(s.rows) - 1; y >= 0; y-- {
+		b.WriteByte('|')
+		for x := range s.chamber.Width {
+			if s.rows[y]&(1<<x) != 0 {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteString("|\n")
 	}
-	return p
+	b.WriteString("+" + strings.Repeat("-", s.chamber.Width) + "+")
+	return b.String()
 }
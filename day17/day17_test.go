@@ -1,10 +1,13 @@
 package day17
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 func TestNewDay(t *testing.T) {
@@ -31,5 +34,136 @@ func TestSolvePartTwo(t *testing.T) {
 	answer, err := day.SolvePartTwo()
 	require.NoError(t, err)
 
-	assert.Equal(t, "", answer)
+	assert.Equal(t, "1514285714288", answer)
+}
+
+func TestTowerHeightShould(t *testing.T) {
+	day := &Day{jetPattern: ">>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>"}
+
+	t.Run("match the simulation of every rock", func(t *testing.T) {
+		sim := newSimulation(day.jetPattern, DefaultChamber())
+		for rocks := 1; rocks <= 500; rocks++ {
+			sim.dropRock()
+			height, err := day.TowerHeight(DefaultChamber(), rocks)
+			require.NoError(t, err)
+			require.Equal(t, sim.height(), height, "after %d rocks", rocks)
+		}
+	})
+
+	t.Run("return 0 without rocks", func(t *testing.T) {
+		height, err := day.TowerHeight(DefaultChamber(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, height)
+	})
+
+	t.Run("support custom shapes and chamber widths", func(t *testing.T) {
+		square, err := ParseShape("##\n##")
+		require.NoError(t, err)
+
+		// squares land pushed to the left and to the right in turns, filling a row of 2 every 2 rocks
+		day := &Day{jetPattern: "<<<<>>>>"}
+		height, err := day.TowerHeight(Chamber{Width: 4, Shapes: []Shape{square}}, 1_000_001)
+		require.NoError(t, err)
+		assert.Equal(t, 1_000_002, height)
+	})
+
+	t.Run("not extrapolate from towers that only look alike from above", func(t *testing.T) {
+		day := &Day{jetPattern: "><<<<>>"}
+		sim := newSimulation(day.jetPattern, DefaultChamber())
+		for range 4000 {
+			sim.dropRock()
+		}
+
+		height, err := day.TowerHeight(DefaultChamber(), 4000)
+		require.NoError(t, err)
+		assert.Equal(t, sim.height(), height)
+	})
+
+	t.Run("match the simulation of every rock with random jet patterns", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+		for range 50 {
+			jets := make([]byte, 1+random.Intn(20))
+			for i := range jets {
+				jets[i] = "<>"[random.Intn(2)]
+			}
+			day := &Day{jetPattern: string(jets)}
+			rocks := random.Intn(3000)
+
+			sim := newSimulation(day.jetPattern, DefaultChamber())
+			for range rocks {
+				sim.dropRock()
+			}
+
+			height, err := day.TowerHeight(DefaultChamber(), rocks)
+			require.NoError(t, err)
+			require.Equal(t, sim.height(), height, "after %d rocks with jet pattern %s", rocks, day.jetPattern)
+		}
+	})
+
+	t.Run("fail with an invalid chamber", func(t *testing.T) {
+		wide, err := ParseShape("######")
+		require.NoError(t, err)
+
+		_, err = day.TowerHeight(Chamber{Width: 65, Shapes: DefaultChamber().Shapes}, 1)
+		assert.EqualError(t, err, "chamber width 65 must be between 1 and 64")
+
+		_, err = day.TowerHeight(Chamber{Width: 7}, 1)
+		assert.EqualError(t, err, "chamber has no shapes")
+
+		_, err = day.TowerHeight(Chamber{Width: 7, Shapes: []Shape{wide}}, 1)
+		assert.EqualError(t, err, "shape 1 of width 6 doesn't fit in a chamber of width 7")
+	})
+}
+
+func TestParseShapeShould(t *testing.T) {
+	t.Run("return the offsets of the cells from the bottom left corner", func(t *testing.T) {
+		shape, err := ParseShape(".#.\n###\n.#.")
+		require.NoError(t, err)
+
+		expected := Shape{
+			cells: []util.Point2{{X: 1, Y: 2}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 0}},
+			width: 3,
+		}
+		assert.Equal(t, expected, shape)
+	})
+
+	t.Run("fail with invalid cells", func(t *testing.T) {
+		_, err := ParseShape("##\n#x")
+		assert.EqualError(t, err, "invalid shape: line 2, column 2: invalid cell 'x'")
+	})
+
+	t.Run("fail without rock", func(t *testing.T) {
+		_, err := ParseShape("..")
+		assert.EqualError(t, err, "invalid shape: it has no rock")
+	})
+}
+
+func TestSimulationShould(t *testing.T) {
+	t.Run("draw the tower like the puzzle", func(t *testing.T) {
+		sim := newSimulation(">>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>", DefaultChamber())
+		for range 3 {
+			sim.dropRock()
+		}
+
+		expected := `|..#....|
+|..#....|
+|####...|
+|..###..|
+|...#...|
+|..####.|
++-------+`
+		assert.Equal(t, expected, sim.String())
+	})
+}
+
+func TestNewDayShould(t *testing.T) {
+	t.Run("fail with an empty jet pattern", func(t *testing.T) {
+		_, err := NewDay("")
+		assert.EqualError(t, err, "empty jet pattern")
+	})
+
+	t.Run("fail with invalid jets", func(t *testing.T) {
+		_, err := NewDay("<>x")
+		assert.EqualError(t, err, "invalid jet 'x' at position 2")
+	})
 }
@@ -2,11 +2,10 @@ package day18
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -14,29 +13,32 @@ type Day struct {
 	cubes []cube
 }
 
-var (
-	// Regex matching a 3D position such as "1,22,333"
-	positionRe = regexp.MustCompile(`^(\d+),(\d+),(\d+)$`)
-)
+// cubePattern matches a 3D position such as "1,22,333"
+const cubePattern = "%d,%d,%d"
 
-type cube struct {
-	x, y, z int
-}
+// cube is the position of a 1x1x1 cube
+type cube = util.Point3
 
 type lavaDroplet struct {
 	surface int
 	cubes   util.Set[cube]
 }
 
-type boundingBox struct {
-	xMin, yMin, xMax, yMax, zMin, zMax int
+func init() {
+	registry.Register(registry.Day{
+		Number:    18,
+		Title:     "Boiling Boulders",
+		InputPath: "day18/day18.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
 }
 
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
-	cubesString := strings.Split(input, "\n")
-
-	cubes, err := parseCubes(cubesString)
+	cubes, err := parse.Each(parse.Lines(input), parseCube)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse cubes: %w", err)
 	}
@@ -58,46 +60,12 @@ func (d Day) SolvePartTwo() (string, error) {
 	return fmt.Sprintf("%d", lavaDroplet.getExteriorSurface()), nil
 }
 
-func parseCubes(cubesString []string) ([]cube, error) {
-	cubes := make([]cube, 0, len(cubesString))
-	for _, cubeString := range cubesString {
-		cube, err := parseCube(cubeString)
-		if err != nil {
-			return nil, fmt.Errorf("coud not parse cube: %w", err)
-		}
-		cubes = append(cubes, cube)
-	}
-	return cubes, nil
-}
-
 func parseCube(cubeString string) (cube, error) {
-	matches := positionRe.FindStringSubmatch(cubeString)
-	if len(matches) != 4 {
-		return cube{}, fmt.Errorf("invalid cube format: %s", cubeString)
+	var c cube
+	if err := parse.Match(cubeString, cubePattern, &c.X, &c.Y, &c.Z); err != nil {
+		return cube{}, fmt.Errorf("could not parse cube: %w", err)
 	}
-
-	x, _ := strconv.Atoi(matches[1])
-	y, _ := strconv.Atoi(matches[2])
-	z, _ := strconv.Atoi(matches[3])
-
-	return cube{x: x, y: y, z: z}, nil
-}
-
-func (c cube) adjacentCubes() []cube {
-	return []cube{
-		{c.x - 1, c.y, c.z},
-		{c.x + 1, c.y, c.z},
-		{c.x, c.y - 1, c.z},
-		{c.x, c.y + 1, c.z},
-		{c.x, c.y, c.z - 1},
-		{c.x, c.y, c.z + 1},
-	}
-}
-
-func (c cube) isInside(boundingBox boundingBox) bool {
-	return c.x >= boundingBox.xMin && c.x <= boundingBox.xMax &&
-		c.y >= boundingBox.yMin && c.y <= boundingBox.yMax &&
-		c.z >= boundingBox.zMin && c.z <= boundingBox.zMax
+	return c, nil
 }
 
 func newLavaDroplet(cubes []cube) lavaDroplet {
@@ -116,7 +84,7 @@ func (l *lavaDroplet) addCube(cube cube) {
 
 func (l *lavaDroplet) numberOfAdjacentCubes(cube cube) int {
 	adjacentCubes := 0
-	for _, c := range cube.adjacentCubes() {
+	for _, c := range cube.Neighbours6() {
 		if l.cubes.Contains(c) {
 			adjacentCubes++
 		}
@@ -129,11 +97,11 @@ func (l *lavaDroplet) getSurface() int {
 }
 
 func (l *lavaDroplet) getExteriorSurface() int {
-	boundingBox := getBoundingBox(l.cubes.Members())
+	// The steam must be able to surround the droplet, so the bounding box leaves a layer of air around it
+	boundingBox := util.BoundingBox3(l.cubes.Members()).Grow(1)
 
-	currentCube := cube{boundingBox.xMin, boundingBox.yMin, boundingBox.zMin}
 	steam, visited := util.NewSet[cube](), util.NewSet[cube]()
-	coverWithSteam(currentCube, l, steam, visited, boundingBox)
+	coverWithSteam(boundingBox.Min, l, steam, visited, boundingBox)
 
 	exteriorSurface := 0
 	for _, c := range steam.Members() {
@@ -142,35 +110,13 @@ func (l *lavaDroplet) getExteriorSurface() int {
 	return exteriorSurface
 }
 
-func getBoundingBox(cubes []cube) boundingBox {
-	xMin, xMax, yMin, yMax, zMin, zMax := cubes[0].x, cubes[0].x, cubes[0].y, cubes[0].y, cubes[0].z, cubes[0].z
-	for _, cube := range cubes {
-		xMin = min(xMin, cube.x)
-		xMax = max(xMax, cube.x)
-
-		yMin = min(yMin, cube.y)
-		yMax = max(yMax, cube.y)
-
-		zMin = min(zMin, cube.z)
-		zMax = max(zMax, cube.z)
-	}
-	return boundingBox{
-		xMin: xMin - 1,
-		xMax: xMax + 1,
-		yMin: yMin - 1,
-		yMax: yMax + 1,
-		zMin: zMin - 1,
-		zMax: zMax + 1,
-	}
-}
-
-func coverWithSteam(currentCube cube, lavaDroplet *lavaDroplet, steam, visited util.Set[cube], boundingBox boundingBox) {
-	if !currentCube.isInside(boundingBox) || lavaDroplet.cubes.Contains(currentCube) || visited.Contains(currentCube) {
+func coverWithSteam(currentCube cube, lavaDroplet *lavaDroplet, steam, visited util.Set[cube], boundingBox util.Box3) {
+	if !boundingBox.Contains(currentCube) || lavaDroplet.cubes.Contains(currentCube) || visited.Contains(currentCube) {
 		return
 	}
 	visited.Add(currentCube)
 
-	for _, c := range currentCube.adjacentCubes() {
+	for _, c := range currentCube.Neighbours6() {
 		if lavaDroplet.cubes.Contains(c) {
 			steam.Add(currentCube)
 		}
@@ -10,8 +10,8 @@ import (
 func TestNewDay(t *testing.T) {
 	expected := &Day{
 		cubes: []cube{
-			{1, 1, 1},
-			{2, 1, 1},
+			{X: 1, Y: 1, Z: 1},
+			{X: 2, Y: 1, Z: 1},
 		},
 	}
 	input := `1,1,1
@@ -1,10 +1,13 @@
 package day19
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -36,6 +39,19 @@ type robotCost struct {
 	obsidian int
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    19,
+		Title:     "Not Enough Minerals",
+		InputPath: "day19/day19.txt",
+		Status:    registry.Slow,
+		Note:      "It takes ~5min to run",
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	blueprintsString := strings.Split(input, "\n")
@@ -50,28 +66,38 @@ func NewDay(input string) (*Day, error) {
 
 // SolvePartOne solves part one
 func (d Day) SolvePartOne() (string, error) {
+	return d.SolvePartOneContext(context.Background())
+}
+
+// SolvePartTwo solves part two
+func (d Day) SolvePartTwo() (string, error) {
+	return d.SolvePartTwoContext(context.Background())
+}
+
+// SolvePartOneContext solves part one, stopping the search when the context is done
+func (d Day) SolvePartOneContext(ctx context.Context) (string, error) {
 	qualityLevelsSum := 0
 	for _, blueprint := range d.blueprints {
-		maxGeodes := 0
-		explore(newState(blueprint), totalMinutesPartOne, &maxGeodes)
+		maxGeodes, err := maxGeodes(ctx, blueprint, totalMinutesPartOne)
+		if err != nil {
+			return "", fmt.Errorf("could not explore blueprint %d: %w", blueprint.ID, err)
+		}
 		qualityLevelsSum += blueprint.qualityLevel(maxGeodes)
 	}
 	return fmt.Sprintf("%d", qualityLevelsSum), nil
-
 }
 
-// SolvePartTwo solves part two
-func (d Day) SolvePartTwo() (string, error) {
-	maxGeodesBlueprint1 := 0
-	explore(newState(d.blueprints[0]), totalMinutesPartTwo, &maxGeodesBlueprint1)
-
-	maxGeodesBlueprint2 := 0
-	explore(newState(d.blueprints[1]), totalMinutesPartTwo, &maxGeodesBlueprint2)
-
-	maxGeodesBlueprint3 := 0
-	explore(newState(d.blueprints[2]), totalMinutesPartTwo, &maxGeodesBlueprint3)
-
-	return fmt.Sprintf("%d", maxGeodesBlueprint1*maxGeodesBlueprint2*maxGeodesBlueprint3), nil
+// SolvePartTwoContext solves part two, stopping the search when the context is done
+func (d Day) SolvePartTwoContext(ctx context.Context) (string, error) {
+	product := 1
+	for _, blueprint := range d.blueprints[:min(3, len(d.blueprints))] {
+		maxGeodes, err := maxGeodes(ctx, blueprint, totalMinutesPartTwo)
+		if err != nil {
+			return "", fmt.Errorf("could not explore blueprint %d: %w", blueprint.ID, err)
+		}
+		product *= maxGeodes
+	}
+	return fmt.Sprintf("%d", product), nil
 }
 
 func parseBlueprints(blueprintsString []string) ([]blueprint, error) {
@@ -148,16 +174,30 @@ func (b blueprint) qualityLevel(geodes int) int {
 	return b.ID * geodes
 }
 
-func explore(state state, totalMinutes int, maxGeodes *int) {
+func maxGeodes(ctx context.Context, blueprint blueprint, totalMinutes int) (int, error) {
+	maxGeodes := 0
+	if err := explore(ctx, newState(blueprint), totalMinutes, &maxGeodes); err != nil {
+		return 0, err
+	}
+	return maxGeodes, nil
+}
+
+func explore(ctx context.Context, state state, totalMinutes int, maxGeodes *int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
 	if state.minute > totalMinutes {
 		if state.geodes > *maxGeodes {
 			*maxGeodes = state.geodes
 		}
-		return
+		return nil
 	}
 
 	if maxBoundOfGeodes(state, totalMinutes) <= *maxGeodes {
-		return
+		return nil
 	}
 
 	actions := getActions(state, totalMinutes)
@@ -166,8 +206,11 @@ func explore(state state, totalMinutes int, maxGeodes *int) {
 
 	for _, action := range actions {
 		state3 := executeAction(state2, action)
-		explore(state3, totalMinutes, maxGeodes)
+		if err := explore(ctx, state3, totalMinutes, maxGeodes); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 func getActions(s state, totalMinutes int) []action {
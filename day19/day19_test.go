@@ -1,7 +1,9 @@
 package day19
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -92,3 +94,33 @@ func TestSolvePartTwo(t *testing.T) {
 
 	assert.Equal(t, "194432", answer)
 }
+
+func TestSolveContextShould(t *testing.T) {
+	day := &Day{
+		blueprints: []blueprint{
+			{
+				ID:                1,
+				oreRobotCost:      robotCost{ore: 4},
+				clayRobotCost:     robotCost{ore: 2},
+				obsidianRobotCost: robotCost{ore: 3, clay: 14},
+				geodeRobotCost:    robotCost{ore: 2, obsidian: 7},
+			},
+		},
+	}
+
+	t.Run("stop part one when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := day.SolvePartOneContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("stop part two when the deadline is exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		_, err := day.SolvePartTwoContext(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
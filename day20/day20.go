@@ -5,11 +5,28 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 // Day holds the data needed to solve part one and part two
 type Day struct {
 	encryptedFile []int
+	// arithmetic applies the decryption key and sums the coordinates, checking overflows if asked to
+	arithmetic util.Arithmetic
+}
+
+func init() {
+	registry.Register(registry.Day{
+		Number:    20,
+		Title:     "Grove Positioning System",
+		InputPath: "day20/day20.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
 }
 
 // NewDay returns a new Day that solves part one and two for the given input
@@ -21,28 +38,38 @@ func NewDay(input string) (*Day, error) {
 		return nil, fmt.Errorf("could not parse encrypted file: %w", err)
 	}
 
-	return &Day{encryptedFile: encryptedFile}, nil
+	return &Day{encryptedFile: encryptedFile, arithmetic: util.DefaultArithmetic}, nil
 }
 
 // SolvePartOne solves part one
 func (d Day) SolvePartOne() (string, error) {
 	f := newFile(d.encryptedFile)
 	f.mix()
-	return fmt.Sprintf("%d", f.getGrooveCoordinates()), nil
+	grooveCoordinates, err := f.getGrooveCoordinates(d.arithmetic)
+	if err != nil {
+		return "", fmt.Errorf("could not get groove coordinates: %w", err)
+	}
+	return fmt.Sprintf("%d", grooveCoordinates), nil
 }
 
 // SolvePartTwo solves part two
 func (d Day) SolvePartTwo() (string, error) {
 	const decryptionKey = 811589153
 
-	applyDecryptionKey(d.encryptedFile, decryptionKey)
+	if err := applyDecryptionKey(d.arithmetic, d.encryptedFile, decryptionKey); err != nil {
+		return "", fmt.Errorf("could not apply decryption key: %w", err)
+	}
 
 	f := newFile(d.encryptedFile)
 	for i := 0; i < 10; i++ {
 		f.mix()
 	}
 
-	return fmt.Sprintf("%d", f.getGrooveCoordinates()), nil
+	grooveCoordinates, err := f.getGrooveCoordinates(d.arithmetic)
+	if err != nil {
+		return "", fmt.Errorf("could not get groove coordinates: %w", err)
+	}
+	return fmt.Sprintf("%d", grooveCoordinates), nil
 }
 
 func parseEncryptedFile(lines []string) ([]int, error) {
@@ -60,10 +87,15 @@ func parseEncryptedFile(lines []string) ([]int, error) {
 	return numbers, nil
 }
 
-func applyDecryptionKey(encryptedFile []int, decryptionKey int) {
-	for i := range encryptedFile {
-		encryptedFile[i] *= decryptionKey
+func applyDecryptionKey(a util.Arithmetic, encryptedFile []int, decryptionKey int) error {
+	for i, number := range encryptedFile {
+		decrypted, err := a.Mul(number, decryptionKey)
+		if err != nil {
+			return fmt.Errorf("number %d at position %d: %w", number, i, err)
+		}
+		encryptedFile[i] = decrypted
 	}
+	return nil
 }
 
 type file struct {
@@ -92,14 +124,24 @@ func (f file) mix() {
 		positionsToMove := f.index[i].Value.(int)
 		previousElement := f.index[i].Prev()
 		removedElement := previousElement.Unlink(1)
-		previousElement.Move(positionsToMove % (n - 1)).Link(removedElement)
+		// Once removed, the element moves around a ring of n-1 elements
+		previousElement.Move(util.Mod(positionsToMove, n-1)).Link(removedElement)
 	}
 }
 
-func (f file) getGrooveCoordinates() int {
+func (f file) getGrooveCoordinates(a util.Arithmetic) (int, error) {
 	r := f.ring
 	for r.Value.(int) != 0 {
 		r = r.Next()
 	}
-	return r.Move(1_000).Value.(int) + r.Move(2_000).Value.(int) + r.Move(3_000).Value.(int)
+
+	sum := 0
+	for _, offset := range []int{1_000, 2_000, 3_000} {
+		number := r.Move(offset).Value.(int)
+		var err error
+		if sum, err = a.Add(sum, number); err != nil {
+			return 0, fmt.Errorf("number %d places after 0: %w", offset, err)
+		}
+	}
+	return sum, nil
 }
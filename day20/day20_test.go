@@ -1,10 +1,13 @@
 package day20
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 func TestNewDay(t *testing.T) {
@@ -45,3 +48,17 @@ func TestSolvePartTwo(t *testing.T) {
 
 	assert.Equal(t, "1623178306", answer)
 }
+
+func TestSolvePartTwoShould(t *testing.T) {
+	t.Run("fail when the decryption key overflows a number when checked", func(t *testing.T) {
+		day := &Day{
+			encryptedFile: []int{1, 0, math.MaxInt / 2},
+			arithmetic:    util.Arithmetic{Checked: true},
+		}
+
+		_, err := day.SolvePartTwo()
+
+		assert.ErrorIs(t, err, util.ErrOverflow)
+		assert.ErrorContains(t, err, "number 4611686018427387903 at position 2")
+	})
+}
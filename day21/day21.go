@@ -1,16 +1,22 @@
 package day21
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 // Day holds the data needed to solve part one and part two
 type Day struct {
 	monkeys map[string]monkey
+	// arithmetic computes the numbers yelled by the monkeys, checking overflows if asked to
+	arithmetic util.Arithmetic
 }
 
 const (
@@ -21,6 +27,9 @@ const (
 var (
 	// Regex matching an operation as "cczh + lfqf"
 	operationRe = regexp.MustCompile(`(\w+) (.) (\w+)`)
+
+	// errUnknownMonkey is returned when a number depends on a monkey that doesn't exist, such as the human in part two
+	errUnknownMonkey = errors.New("unknown monkey")
 )
 
 type monkey struct {
@@ -29,12 +38,30 @@ type monkey struct {
 	operation *operation
 }
 
+// yell holds the number yelled by a monkey, or why it can't be yelled
+type yell struct {
+	number int
+	err    error
+}
+
 type operation struct {
 	left     string
 	operator string
 	right    string
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    21,
+		Title:     "Monkey Math",
+		InputPath: "day21/day21.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	monkeysString := strings.Split(input, "\n")
@@ -43,32 +70,36 @@ func NewDay(input string) (*Day, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not parse monkeys: %w", err)
 	}
-	return &Day{monkeys: monkeys}, nil
+	return &Day{monkeys: monkeys, arithmetic: util.DefaultArithmetic}, nil
 }
 
 // SolvePartOne solves part one
 func (d Day) SolvePartOne() (string, error) {
 
-	number, err := yell(rootMonkeyName, d.monkeys)
-	if err != nil {
-		return "", fmt.Errorf("failed to find number yelled by root monkey: %w", err)
+	root := d.yells().Get(rootMonkeyName)
+	if root.err != nil {
+		return "", fmt.Errorf("failed to find number yelled by root monkey: %w", root.err)
 	}
 
-	return fmt.Sprintf("%d", number), nil
+	return fmt.Sprintf("%d", root.number), nil
 }
 
 // SolvePartTwo solves part two
 func (d Day) SolvePartTwo() (string, error) {
 	delete(d.monkeys, humanName)
+	yells := d.yells()
 
 	name := d.monkeys[rootMonkeyName].operation.right
-	target, err := yell(d.monkeys[rootMonkeyName].operation.left, d.monkeys)
-	if err != nil {
+	target := yells.Get(d.monkeys[rootMonkeyName].operation.left)
+	if errors.Is(target.err, errUnknownMonkey) {
 		name = d.monkeys[rootMonkeyName].operation.left
-		target, _ = yell(d.monkeys[rootMonkeyName].operation.right, d.monkeys)
+		target = yells.Get(d.monkeys[rootMonkeyName].operation.right)
+	}
+	if target.err != nil {
+		return "", fmt.Errorf("failed to find number to match: %w", target.err)
 	}
 
-	numberYelledByHuman, err := yellWithTarget(name, d.monkeys, target)
+	numberYelledByHuman, err := d.yellWithTarget(yells, name, target.number)
 	if err != nil {
 		return "", fmt.Errorf("failed to find number yelled by human: %w", err)
 	}
@@ -120,78 +151,105 @@ func parseMonkey(monkeyString string) (monkey, error) {
 	}, nil
 }
 
-func yell(name string, monkeys map[string]monkey) (int, error) {
-	m, ok := monkeys[name]
-	if !ok {
-		return 0, fmt.Errorf("could not find monkey with name %s", name)
-	}
+// yells returns the numbers yelled by the monkeys, failing if any operation overflows when the arithmetic is checked.
+// Each monkey is only computed once, since part two asks again for the monkeys below each step towards the human.
+func (d Day) yells() *util.Memo[string, yell] {
+	return util.NewMemo(func(recurse func(string) yell, name string) yell {
+		m, ok := d.monkeys[name]
+		if !ok {
+			return yell{err: fmt.Errorf("%w: %s", errUnknownMonkey, name)}
+		}
 
-	if m.operation == nil {
-		return m.number, nil
-	}
+		if m.operation == nil {
+			return yell{number: m.number}
+		}
 
-	left, err := yell(m.operation.left, monkeys)
-	if err != nil {
-		return 0, err
-	}
+		left := recurse(m.operation.left)
+		if left.err != nil {
+			return left
+		}
 
-	right, err := yell(m.operation.right, monkeys)
-	if err != nil {
-		return 0, err
-	}
+		right := recurse(m.operation.right)
+		if right.err != nil {
+			return right
+		}
 
-	switch m.operation.operator {
-	case "+":
-		return left + right, nil
-	case "-":
-		return left - right, nil
-	case "*":
-		return left * right, nil
-	case "/":
-		return left / right, nil
-	default:
-		return 0, fmt.Errorf("invalid operator: %s", m.operation.operator)
-	}
+		number, err := m.operation.apply(d.arithmetic, left.number, right.number)
+		if err != nil {
+			return yell{err: fmt.Errorf("monkey %s could not yell: %w", name, err)}
+		}
+		return yell{number: number}
+	})
 }
 
-func yellWithTarget(name string, monkeys map[string]monkey, target int) (int, error) {
+// yellWithTarget returns the number the human must yell for the given monkey to yell the target
+func (d Day) yellWithTarget(yells *util.Memo[string, yell], name string, target int) (int, error) {
 	if name == humanName {
 		return target, nil
 	}
 
-	m, ok := monkeys[name]
+	m, ok := d.monkeys[name]
 	if !ok {
-		return 0, fmt.Errorf("could not find monkey with name %s", name)
+		return 0, fmt.Errorf("%w: %s", errUnknownMonkey, name)
+	}
+	if m.operation == nil {
+		return 0, fmt.Errorf("monkey %s yells %d regardless of the human", name, m.number)
 	}
 
-	number, err := yell(m.operation.left, monkeys)
+	// Only one side depends on the human, the other one yields the known operand
+	unknown := m.operation.right
+	known := yells.Get(m.operation.left)
+	unknownOnLeft := errors.Is(known.err, errUnknownMonkey)
+	if unknownOnLeft {
+		unknown = m.operation.left
+		known = yells.Get(m.operation.right)
+	}
+	if known.err != nil {
+		return 0, known.err
+	}
+
+	next, err := m.operation.solve(d.arithmetic, target, known.number, unknownOnLeft)
 	if err != nil {
-		// We know this won't fail because the human is on the left path.
-		number, _ = yell(m.operation.right, monkeys)
-		switch m.operation.operator {
-		case "+":
-			return yellWithTarget(m.operation.left, monkeys, target-number)
-		case "-":
-			return yellWithTarget(m.operation.left, monkeys, target+number)
-		case "*":
-			return yellWithTarget(m.operation.left, monkeys, target/number)
-		case "/":
-			return yellWithTarget(m.operation.left, monkeys, target*number)
-		default:
-			return 0, fmt.Errorf("invalid operator: %s", m.operation.operator)
-		}
+		return 0, fmt.Errorf("monkey %s could not yell %d: %w", name, target, err)
 	}
+	return d.yellWithTarget(yells, unknown, next)
+}
 
-	switch m.operation.operator {
+// apply returns the result of the operation, failing if it overflows when the arithmetic is checked
+func (o operation) apply(a util.Arithmetic, left, right int) (int, error) {
+	switch o.operator {
 	case "+":
-		return yellWithTarget(m.operation.right, monkeys, target-number)
+		return a.Add(left, right)
 	case "-":
-		return yellWithTarget(m.operation.right, monkeys, number-target)
+		return a.Sub(left, right)
 	case "*":
-		return yellWithTarget(m.operation.right, monkeys, target/number)
+		return a.Mul(left, right)
 	case "/":
-		return yellWithTarget(m.operation.right, monkeys, number/target)
+		return a.Div(left, right)
+	default:
+		return 0, fmt.Errorf("invalid operator: %s", o.operator)
+	}
+}
+
+// solve returns the unknown operand for the operation to result in the target, given the known operand
+func (o operation) solve(a util.Arithmetic, target, known int, unknownOnLeft bool) (int, error) {
+	switch {
+	case o.operator == "+":
+		return a.Sub(target, known)
+	case o.operator == "-" && unknownOnLeft:
+		return a.Add(target, known)
+	case o.operator == "-":
+		return a.Sub(known, target)
+	case o.operator == "*":
+		if known == 0 || target%known != 0 {
+			return 0, fmt.Errorf("no integer multiplied by %d is %d", known, target)
+		}
+		return target / known, nil
+	case o.operator == "/" && unknownOnLeft:
+		return a.Mul(target, known)
+	case o.operator == "/":
+		return a.Div(known, target)
 	default:
-		return 0, fmt.Errorf("invalid operator: %s", m.operation.operator)
+		return 0, fmt.Errorf("invalid operator: %s", o.operator)
 	}
 }
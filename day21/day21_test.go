@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 func TestNewDay(t *testing.T) {
@@ -170,3 +172,51 @@ hmdt: 32`
 
 	assert.Equal(t, "301", answer)
 }
+
+func TestSolvePartOneShould(t *testing.T) {
+	t.Run("fail with the monkey whose number overflows when checked", func(t *testing.T) {
+		input := `root: pppw + sjmn
+pppw: cczh * lfqf
+cczh: 3
+lfqf: 4611686018427387904
+sjmn: 1`
+		day, err := NewDay(input)
+		require.NoError(t, err)
+		day.arithmetic = util.Arithmetic{Checked: true}
+
+		_, err = day.SolvePartOne()
+
+		assert.ErrorIs(t, err, util.ErrOverflow)
+		assert.ErrorContains(t, err, "monkey pppw could not yell")
+	})
+
+	t.Run("wrap around on overflow by default", func(t *testing.T) {
+		input := `root: pppw + sjmn
+pppw: cczh * lfqf
+cczh: 2
+lfqf: 4611686018427387904
+sjmn: 1`
+		day, err := NewDay(input)
+		require.NoError(t, err)
+
+		answer, err := day.SolvePartOne()
+		require.NoError(t, err)
+		assert.Equal(t, "-9223372036854775807", answer)
+	})
+}
+
+func TestSolvePartTwoShould(t *testing.T) {
+	t.Run("fail when no integer makes both numbers match", func(t *testing.T) {
+		input := `root: pppw = sjmn
+pppw: humn * lfqf
+lfqf: 2
+sjmn: 5
+humn: 1`
+		day, err := NewDay(input)
+		require.NoError(t, err)
+
+		_, err = day.SolvePartTwo()
+
+		assert.ErrorContains(t, err, "monkey pppw could not yell 5: no integer multiplied by 2 is 5")
+	})
+}
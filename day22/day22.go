@@ -3,10 +3,14 @@ package day22
 import (
 	"cmp"
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 // Day holds the data needed to solve part one and part two
@@ -58,6 +62,18 @@ type position struct {
 	facing facing
 }
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    22,
+		Title:     "Monkey Map",
+		InputPath: "day22/day22.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	lines := strings.Split(input, "\n")
@@ -78,16 +94,26 @@ func NewDay(input string) (*Day, error) {
 
 // SolvePartOne solves part one
 func (d Day) SolvePartOne() (string, error) {
-	p := startingPosition(d.board)
-	for _, s := range d.path {
-		p = s.execute(p, d.board)
-	}
-	return fmt.Sprintf("%d", p.finalPassword()), nil
+	next := func(p position) position { return nextPosition(p, d.board) }
+	return fmt.Sprintf("%d", d.walk(next).finalPassword()), nil
 }
 
 // SolvePartTwo solves part two
 func (d Day) SolvePartTwo() (string, error) {
-	return "", nil
+	c, err := newCube(d.board)
+	if err != nil {
+		return "", fmt.Errorf("could not fold board into a cube: %w", err)
+	}
+	return fmt.Sprintf("%d", d.walk(c.nextPosition).finalPassword()), nil
+}
+
+// walk follows the path from the starting position, where next returns the position after moving one tile
+func (d Day) walk(next func(position) position) position {
+	p := startingPosition(d.board)
+	for _, s := range d.path {
+		p = s.execute(p, d.board, next)
+	}
+	return p
 }
 
 func parseBoard(lines []string) ([][]square, error) {
@@ -147,21 +173,21 @@ func startingPosition(board [][]square) position {
 	}
 }
 
-func (s step) execute(p position, board [][]square) position {
+func (s step) execute(p position, board [][]square, next func(position) position) position {
 	if s.turn == noTurn {
-		return executeMove(p, board, s.tilesToMove)
+		return executeMove(p, board, next, s.tilesToMove)
 	}
 	return executeTurn(p, s.turn)
 }
 
-func executeMove(p position, board [][]square, tilesToMove int) position {
+func executeMove(p position, board [][]square, next func(position) position, tilesToMove int) position {
 	if tilesToMove <= 0 {
 		return p
 	}
-	nextP := nextPosition(p, board)
+	nextP := next(p)
 	switch board[nextP.i][nextP.j] {
 	case tile:
-		return executeMove(nextP, board, tilesToMove-1)
+		return executeMove(nextP, board, next, tilesToMove-1)
 	case wall:
 		return p
 	}
@@ -204,6 +230,10 @@ func (f facing) turnCounterClockwise() facing {
 	return f.turnClockwise().turnClockwise().turnClockwise()
 }
 
+func (f facing) opposite() facing {
+	return f.turnClockwise().turnClockwise()
+}
+
 func (f facing) code() int {
 	switch f {
 	case right:
@@ -242,3 +272,193 @@ func nextPosition(p position, board [][]square) position {
 func (p position) finalPassword() int {
 	return 1000*(p.i+1) + 4*(p.j+1) + p.facing.code()
 }
+
+// cube is the board folded into a cube, where moving past the edge of a face continues on the face glued to it
+type cube struct {
+	size int
+	// faces holds the orientation in the cube of each face, by its row and column in the net of faces of the board
+	faces map[util.Point2]face
+	// edges maps each edge to the edge of the face glued to it
+	edges map[edge]edge
+}
+
+// face holds the orientation of a face in the cube as unit vectors: the one pointing outside the cube and the ones
+// pointing to the right and downwards of the face as drawn in the board
+type face struct {
+	normal, right, down util.Point3
+}
+
+// edge is a side of a face, identified with the facing that leaves the face through it
+type edge struct {
+	face util.Point2
+	side facing
+}
+
+// newCube folds the board into a cube, the size of the faces and how they are glued together are deduced from the board
+func newCube(board [][]square) (*cube, error) {
+	size, err := faceSize(board)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &cube{size: size, faces: make(map[util.Point2]face), edges: make(map[edge]edge)}
+	start := util.Point2{X: startingPosition(board).j / size, Y: 0}
+	c.faces[start] = face{normal: util.Point3{Z: -1}, right: util.Point3{X: 1}, down: util.Point3{Y: 1}}
+
+	// fold the faces that are next to each other in the board, starting from the face where the path starts
+	queue := util.NewDeque(start)
+	for queue.Len() > 0 {
+		current := queue.PopFront()
+		for _, side := range []facing{up, right, down, left} {
+			next := current.Add(side.offset())
+			if _, ok := c.faces[next]; ok || !c.isFace(board, next) {
+				continue
+			}
+			c.faces[next] = c.faces[current].fold(side)
+			queue.PushBack(next)
+		}
+	}
+	if len(c.faces) != 6 {
+		return nil, fmt.Errorf("board has %d connected faces of size %d but a cube has 6", len(c.faces), size)
+	}
+
+	// two faces are glued by the edge where each one points to the other
+	normals := make(map[util.Point3]util.Point2, len(c.faces))
+	positions := slices.SortedFunc(maps.Keys(c.faces), func(a, b util.Point2) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	for _, position := range positions {
+		f := c.faces[position]
+		if other, ok := normals[f.normal]; ok {
+			return nil, fmt.Errorf("faces at %v and %v overlap once folded", other, position)
+		}
+		normals[f.normal] = position
+	}
+	for position, f := range c.faces {
+		for _, side := range []facing{up, right, down, left} {
+			glued := normals[f.outwards(side)]
+			for _, gluedSide := range []facing{up, right, down, left} {
+				if c.faces[glued].outwards(gluedSide) == f.normal {
+					c.edges[edge{face: position, side: side}] = edge{face: glued, side: gluedSide}
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+// faceSize returns the length of the side of the faces, given that the board has the area of 6 faces
+func faceSize(board [][]square) (int, error) {
+	area := 0
+	for _, row := range board {
+		for _, sq := range row {
+			if sq != empty {
+				area++
+			}
+		}
+	}
+	size := 1
+	for size*size*6 < area {
+		size++
+	}
+	if size*size*6 != area {
+		return 0, fmt.Errorf("board has %d squares, which is not the area of a cube", area)
+	}
+	return size, nil
+}
+
+// isFace returns true if the face at the given row and column of the net is part of the board.
+// It doesn't check the whole face, faceSize already made sure that the board has the area of 6 faces.
+func (c *cube) isFace(board [][]square, p util.Point2) bool {
+	i, j := p.Y*c.size, p.X*c.size
+	return i >= 0 && i < len(board) && j >= 0 && j < len(board[i]) && board[i][j] != empty
+}
+
+// nextPosition returns the position after moving one tile, wrapping around the edges of the faces of the cube
+func (c *cube) nextPosition(p position) position {
+	i, j := p.i%c.size, p.j%c.size
+	last := c.size - 1
+	leaves := (p.facing == up && i == 0) || (p.facing == down && i == last) ||
+		(p.facing == left && j == 0) || (p.facing == right && j == last)
+	if !leaves {
+		offset := p.facing.offset()
+		return position{i: p.i + offset.Y, j: p.j + offset.X, facing: p.facing}
+	}
+
+	from := edge{face: util.Point2{X: p.j / c.size, Y: p.i / c.size}, side: p.facing}
+	to := c.edges[from]
+
+	// the distance along the edge is kept when the edges run in the same direction in the cube, and reversed otherwise
+	along := j
+	if from.side == left || from.side == right {
+		along = i
+	}
+	if c.faces[from.face].along(from.side) != c.faces[to.face].along(to.side) {
+		along = last - along
+	}
+
+	switch to.side {
+	case up:
+		i, j = 0, along
+	case down:
+		i, j = last, along
+	case left:
+		i, j = along, 0
+	case right:
+		i, j = along, last
+	}
+	return position{i: to.face.Y*c.size + i, j: to.face.X*c.size + j, facing: to.side.opposite()}
+}
+
+// fold returns the face next to this one in the given side of the net, once folded over the edge between them
+func (f face) fold(side facing) face {
+	switch side {
+	case up:
+		return face{normal: f.down.Scale(-1), right: f.right, down: f.normal}
+	case down:
+		return face{normal: f.down, right: f.right, down: f.normal.Scale(-1)}
+	case left:
+		return face{normal: f.right.Scale(-1), right: f.normal, down: f.down}
+	case right:
+		return face{normal: f.right, right: f.normal.Scale(-1), down: f.down}
+	}
+	panic("BUG! invalid facing")
+}
+
+// outwards returns the vector that leaves the face through the edge of the given side
+func (f face) outwards(side facing) util.Point3 {
+	switch side {
+	case up:
+		return f.down.Scale(-1)
+	case down:
+		return f.down
+	case left:
+		return f.right.Scale(-1)
+	case right:
+		return f.right
+	}
+	panic("BUG! invalid facing")
+}
+
+// along returns the vector that runs along the edge of the given side, in the direction the rows or columns grow
+func (f face) along(side facing) util.Point3 {
+	if side == left || side == right {
+		return f.down
+	}
+	return f.right
+}
+
+// offset returns the change of row and column after moving one tile with this facing
+func (f facing) offset() util.Point2 {
+	switch f {
+	case up:
+		return util.North
+	case down:
+		return util.South
+	case left:
+		return util.West
+	case right:
+		return util.East
+	}
+	panic("BUG! invalid facing")
+}
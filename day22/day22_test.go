@@ -7,6 +7,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const exampleInput = `        ...#
+        .#..
+        #...
+        ....
+...#.......#
+........#...
+..#....#....
+..........#.
+        ...#....
+        .....#..
+        .#......
+        ......#.
+
+10R5L5R10L4R5L5`
+
 func TestNewDay(t *testing.T) {
 	expected := &Day{
 		board: [][]square{
@@ -62,21 +77,7 @@ func TestNewDay(t *testing.T) {
 }
 
 func TestSolvePartOne(t *testing.T) {
-	input := `        ...#
-        .#..
-        #...
-        ....
-...#.......#
-........#...
-..#....#....
-..........#.
-        ...#....
-        .....#..
-        .#......
-        ......#.
-
-10R5L5R10L4R5L5`
-	day, err := NewDay(input)
+	day, err := NewDay(exampleInput)
 	require.NoError(t, err)
 
 	answer, err := day.SolvePartOne()
@@ -86,10 +87,83 @@ func TestSolvePartOne(t *testing.T) {
 }
 
 func TestSolvePartTwo(t *testing.T) {
-	day := &Day{}
+	day, err := NewDay(exampleInput)
+	require.NoError(t, err)
 
 	answer, err := day.SolvePartTwo()
 	require.NoError(t, err)
 
-	assert.Equal(t, "", answer)
+	assert.Equal(t, "5031", answer)
+}
+
+func TestNewCubeShould(t *testing.T) {
+	day, err := NewDay(exampleInput)
+	require.NoError(t, err)
+
+	t.Run("detect the face size", func(t *testing.T) {
+		c, err := newCube(day.board)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, c.size)
+		assert.Len(t, c.faces, 6)
+		assert.Len(t, c.edges, 24)
+	})
+
+	t.Run("fail when the board doesn't have the area of a cube", func(t *testing.T) {
+		_, err := newCube([][]square{{tile, tile, tile}})
+		assert.EqualError(t, err, "board has 3 squares, which is not the area of a cube")
+	})
+
+	t.Run("fail when the faces don't fold into a cube", func(t *testing.T) {
+		_, err := newCube([][]square{{tile, tile, tile, tile, tile, tile}})
+		assert.EqualError(t, err, "faces at {0 0} and {4 0} overlap once folded")
+	})
+
+	t.Run("fail when the faces are not connected", func(t *testing.T) {
+		_, err := newCube([][]square{
+			{tile, tile, tile, tile, tile},
+			{empty, empty, empty, empty, empty},
+			{tile, empty, empty, empty, empty},
+		})
+		assert.EqualError(t, err, "board has 5 connected faces of size 1 but a cube has 6")
+	})
+}
+
+func TestCubeNextPositionShould(t *testing.T) {
+	day, err := NewDay(exampleInput)
+	require.NoError(t, err)
+	c, err := newCube(day.board)
+	require.NoError(t, err)
+
+	t.Run("move inside a face", func(t *testing.T) {
+		assert.Equal(t, position{i: 5, j: 10, facing: right}, c.nextPosition(position{i: 5, j: 9, facing: right}))
+	})
+
+	t.Run("move between faces next to each other in the board", func(t *testing.T) {
+		assert.Equal(t, position{i: 4, j: 8, facing: down}, c.nextPosition(position{i: 3, j: 8, facing: down}))
+	})
+
+	t.Run("wrap around the edges of the cube like the example", func(t *testing.T) {
+		assert.Equal(t, position{i: 8, j: 14, facing: down}, c.nextPosition(position{i: 5, j: 11, facing: right}))
+		assert.Equal(t, position{i: 7, j: 1, facing: up}, c.nextPosition(position{i: 11, j: 10, facing: down}))
+	})
+
+	t.Run("come back when turning around after crossing any edge", func(t *testing.T) {
+		for i, row := range day.board {
+			for j, sq := range row {
+				if sq == empty {
+					continue
+				}
+				for _, f := range []facing{up, right, down, left} {
+					p := position{i: i, j: j, facing: f}
+					next := c.nextPosition(p)
+					require.NotEqual(t, empty, day.board[next.i][next.j], "from %v", p)
+
+					next.facing = next.facing.opposite()
+					back := c.nextPosition(next)
+					require.Equal(t, position{i: i, j: j, facing: f.opposite()}, back, "from %v", p)
+				}
+			}
+		}
+	})
 }
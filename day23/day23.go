@@ -1,11 +1,10 @@
 package day23
 
 import (
-	"cmp"
 	"fmt"
-	"slices"
 	"strings"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
@@ -20,11 +19,7 @@ type grove struct {
 }
 
 type elf struct {
-	position position
-}
-
-type position struct {
-	i, j int
+	position util.Point2
 }
 
 type direction string
@@ -36,6 +31,18 @@ const (
 	east  direction = "E"
 )
 
+func init() {
+	registry.Register(registry.Day{
+		Number:    23,
+		Title:     "Unstable Diffusion",
+		InputPath: "day23/day23.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
+}
+
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	lines := strings.Split(input, "\n")
@@ -74,7 +81,7 @@ func parseElves(lines []string) ([]*elf, error) {
 	for i, line := range lines {
 		for j, r := range line {
 			if r == '#' {
-				elves = append(elves, &elf{position{i: i, j: j}})
+				elves = append(elves, &elf{util.Point2{X: j, Y: i}})
 			}
 		}
 	}
@@ -95,13 +102,13 @@ func (g *grove) executeRound() int {
 	return moves
 }
 
-func (g *grove) executeFirstHalf() map[position][]*elf {
-	elvesPositions := util.NewSet[position]()
+func (g *grove) executeFirstHalf() map[util.Point2][]*elf {
+	elvesPositions := util.NewSet[util.Point2]()
 	for _, elf := range g.elves {
 		elvesPositions.Add(elf.position)
 	}
 
-	proposedPositions := make(map[position][]*elf)
+	proposedPositions := make(map[util.Point2][]*elf)
 	for _, elf := range g.elves {
 		proposedPosition := elf.proposePosition(elvesPositions, g.directions)
 		proposedPositions[proposedPosition] = append(proposedPositions[proposedPosition], elf)
@@ -109,7 +116,7 @@ func (g *grove) executeFirstHalf() map[position][]*elf {
 	return proposedPositions
 }
 
-func (g *grove) executeSecondHalf(proposedPositions map[position][]*elf) int {
+func (g *grove) executeSecondHalf(proposedPositions map[util.Point2][]*elf) int {
 	moves := 0
 	for p, elves := range proposedPositions {
 		if len(elves) == 1 && elves[0].position != p {
@@ -125,14 +132,14 @@ func (g *grove) rotateListOfDirections() {
 }
 
 func (g *grove) emptyGroundTiles() int {
-	minI := slices.MinFunc(g.elves, func(a, b *elf) int { return cmp.Compare(a.position.i, b.position.i) }).position.i
-	maxI := slices.MaxFunc(g.elves, func(a, b *elf) int { return cmp.Compare(a.position.i, b.position.i) }).position.i
-	minJ := slices.MinFunc(g.elves, func(a, b *elf) int { return cmp.Compare(a.position.j, b.position.j) }).position.j
-	maxJ := slices.MaxFunc(g.elves, func(a, b *elf) int { return cmp.Compare(a.position.j, b.position.j) }).position.j
-	return (maxI-minI+1)*(maxJ-minJ+1) - len(g.elves)
+	ground := util.NewSparseGrid[*elf]()
+	for _, elf := range g.elves {
+		ground.Set(elf.position, elf)
+	}
+	return ground.Area() - ground.Len()
 }
 
-func (e *elf) proposePosition(elvesPositions util.Set[position], directions []direction) position {
+func (e *elf) proposePosition(elvesPositions util.Set[util.Point2], directions []direction) util.Point2 {
 	adjacentElvesPositions := e.adjacentElvesPositions(elvesPositions)
 
 	if len(adjacentElvesPositions) == 0 {
@@ -154,51 +161,42 @@ func (e *elf) proposePosition(elvesPositions util.Set[position], directions []di
 	return e.position
 }
 
-func (d direction) move(p position) position {
+func (d direction) move(p util.Point2) util.Point2 {
 	switch d {
 	case north:
-		return position{i: p.i - 1, j: p.j}
+		return p.Add(util.North)
 	case south:
-		return position{i: p.i + 1, j: p.j}
+		return p.Add(util.South)
 	case west:
-		return position{i: p.i, j: p.j - 1}
+		return p.Add(util.West)
 	case east:
-		return position{i: p.i, j: p.j + 1}
+		return p.Add(util.East)
 	}
 	panic("BUG! invalid position")
 }
 
-func (d direction) adjacentPositions(p position) []position {
+func (d direction) adjacentPositions(p util.Point2) []util.Point2 {
 	switch d {
 	case north:
-		return []position{{i: p.i - 1, j: p.j - 1}, {i: p.i - 1, j: p.j}, {i: p.i - 1, j: p.j + 1}}
+		return []util.Point2{p.Add(util.NorthWest), p.Add(util.North), p.Add(util.NorthEast)}
 	case south:
-		return []position{{i: p.i + 1, j: p.j - 1}, {i: p.i + 1, j: p.j}, {i: p.i + 1, j: p.j + 1}}
+		return []util.Point2{p.Add(util.SouthWest), p.Add(util.South), p.Add(util.SouthEast)}
 	case west:
-		return []position{{i: p.i - 1, j: p.j - 1}, {i: p.i, j: p.j - 1}, {i: p.i + 1, j: p.j - 1}}
+		return []util.Point2{p.Add(util.NorthWest), p.Add(util.West), p.Add(util.SouthWest)}
 	case east:
-		return []position{{i: p.i - 1, j: p.j + 1}, {i: p.i, j: p.j + 1}, {i: p.i + 1, j: p.j + 1}}
+		return []util.Point2{p.Add(util.NorthEast), p.Add(util.East), p.Add(util.SouthEast)}
 	}
 	return nil
 }
 
-func (e *elf) adjacentPositions() []position {
-	adjacentPositions := make([]position, 0, 8)
-	for i := -1; i <= 1; i++ {
-		for j := -1; j <= 1; j++ {
-			p := position{i: e.position.i + i, j: e.position.j + j}
-			if p != e.position {
-				adjacentPositions = append(adjacentPositions, p)
-			}
-		}
-	}
-	return adjacentPositions
+func (e *elf) adjacentPositions() []util.Point2 {
+	return e.position.Neighbours8()
 }
 
-func (e *elf) adjacentElvesPositions(elvesPositions util.Set[position]) util.Set[position] {
+func (e *elf) adjacentElvesPositions(elvesPositions util.Set[util.Point2]) util.Set[util.Point2] {
 	adjacentPositions := e.adjacentPositions()
 
-	adjacentElvesPositions := util.NewSet[position]()
+	adjacentElvesPositions := util.NewSet[util.Point2]()
 	for _, p := range adjacentPositions {
 		if elvesPositions.Contains(p) {
 			adjacentElvesPositions.Add(p)
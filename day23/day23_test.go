@@ -5,16 +5,18 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 func TestNewDay(t *testing.T) {
 	expected := &Day{
 		elves: []*elf{
-			{position: position{i: 1, j: 2}},
-			{position: position{i: 1, j: 3}},
-			{position: position{i: 2, j: 2}},
-			{position: position{i: 4, j: 2}},
-			{position: position{i: 4, j: 3}},
+			{position: util.Point2{X: 2, Y: 1}},
+			{position: util.Point2{X: 3, Y: 1}},
+			{position: util.Point2{X: 2, Y: 2}},
+			{position: util.Point2{X: 2, Y: 4}},
+			{position: util.Point2{X: 3, Y: 4}},
 		},
 	}
 	input := `.....
@@ -74,17 +76,17 @@ func TestSolvePartTwo(t *testing.T) {
 }
 
 func TestAdjacentPositions(t *testing.T) {
-	e := elf{position: position{i: 10, j: 5}}
-
-	expectedAdjacentPositions := []position{
-		{i: 9, j: 4},
-		{i: 9, j: 5},
-		{i: 9, j: 6},
-		{i: 10, j: 4},
-		{i: 10, j: 6},
-		{i: 11, j: 4},
-		{i: 11, j: 5},
-		{i: 11, j: 6},
+	e := elf{position: util.Point2{X: 5, Y: 10}}
+
+	expectedAdjacentPositions := []util.Point2{
+		{X: 4, Y: 9},
+		{X: 5, Y: 9},
+		{X: 6, Y: 9},
+		{X: 4, Y: 10},
+		{X: 6, Y: 10},
+		{X: 4, Y: 11},
+		{X: 5, Y: 11},
+		{X: 6, Y: 11},
 	}
 	assert.ElementsMatch(t, expectedAdjacentPositions, e.adjacentPositions())
 }
@@ -92,45 +94,45 @@ func TestAdjacentPositions(t *testing.T) {
 func TestDirectionsAdjacentPositionsShould(t *testing.T) {
 	tests := map[string]struct {
 		input    direction
-		expected []position
+		expected []util.Point2
 	}{
 		"return N, NE, NW positions when direction is north": {
 			input: north,
-			expected: []position{
-				{i: 9, j: 4},
-				{i: 9, j: 5},
-				{i: 9, j: 6},
+			expected: []util.Point2{
+				{X: 4, Y: 9},
+				{X: 5, Y: 9},
+				{X: 6, Y: 9},
 			},
 		},
 		"return S, SE, SW positions when direction is south": {
 			input: south,
-			expected: []position{
-				{i: 11, j: 4},
-				{i: 11, j: 5},
-				{i: 11, j: 6},
+			expected: []util.Point2{
+				{X: 4, Y: 11},
+				{X: 5, Y: 11},
+				{X: 6, Y: 11},
 			},
 		},
 		"return W, NW, SW positions when direction is west": {
 			input: west,
-			expected: []position{
-				{i: 9, j: 4},
-				{i: 10, j: 4},
-				{i: 11, j: 4},
+			expected: []util.Point2{
+				{X: 4, Y: 9},
+				{X: 4, Y: 10},
+				{X: 4, Y: 11},
 			},
 		},
 		"return E, NE, SE positions when direction is east": {
 			input: east,
-			expected: []position{
-				{i: 9, j: 6},
-				{i: 10, j: 6},
-				{i: 11, j: 6},
+			expected: []util.Point2{
+				{X: 6, Y: 9},
+				{X: 6, Y: 10},
+				{X: 6, Y: 11},
 			},
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			assert.Equal(t, test.expected, test.input.adjacentPositions(position{i: 10, j: 5}))
+			assert.Equal(t, test.expected, test.input.adjacentPositions(util.Point2{X: 5, Y: 10}))
 		})
 	}
 }
@@ -138,29 +140,29 @@ func TestDirectionsAdjacentPositionsShould(t *testing.T) {
 func TestDirectionsMoveShould(t *testing.T) {
 	tests := map[string]struct {
 		input    direction
-		expected position
+		expected util.Point2
 	}{
 		"return N position when direction is north": {
 			input:    north,
-			expected: position{i: 9, j: 5},
+			expected: util.Point2{X: 5, Y: 9},
 		},
 		"return S position when direction is south": {
 			input:    south,
-			expected: position{i: 11, j: 5},
+			expected: util.Point2{X: 5, Y: 11},
 		},
 		"return W position when direction is west": {
 			input:    west,
-			expected: position{i: 10, j: 4},
+			expected: util.Point2{X: 4, Y: 10},
 		},
 		"return E position when direction is east": {
 			input:    east,
-			expected: position{i: 10, j: 6},
+			expected: util.Point2{X: 6, Y: 10},
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			assert.Equal(t, test.expected, test.input.move(position{i: 10, j: 5}))
+			assert.Equal(t, test.expected, test.input.move(util.Point2{X: 5, Y: 10}))
 		})
 	}
 }
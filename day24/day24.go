@@ -1,15 +1,21 @@
 package day24
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
 	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
+	"github.com/OctaviPascual/AdventOfCode2022/util/search"
 )
 
 // Day holds the data needed to solve part one and part two
 type Day struct {
-	valley [][]rune
+	valley util.Grid[rune]
+	// start and goal are the only ground tiles of the top and bottom rows
+	start, goal util.Point2
 }
 
 const (
@@ -21,234 +27,162 @@ const (
 	ground        = '.'
 )
 
-type expedition struct {
-	valley            [][]rune
-	blizzards         []*blizzard
-	current           position
-	goal              position
-	minutes           int
-	minDistanceToGoal int
+// state holds the position of the expedition and the minute modulo the period of the blizzards,
+// since the valley looks the same at minutes that only differ by a period
+type state struct {
+	position util.Point2
+	minute   int
 }
 
-type position struct {
-	i, j int
-}
+// moves holds the directions the expedition can take each minute, including waiting
+var moves = append([]util.Point2{{}}, util.Compass4...)
 
-type blizzard struct {
-	direction rune
-	position  position
+func init() {
+	registry.Register(registry.Day{
+		Number:    24,
+		Title:     "Blizzard Basin",
+		InputPath: "day24/day24.txt",
+		Status:    registry.Solved,
+		New: func(input string) (registry.Solver, error) {
+			return NewDay(input)
+		},
+	})
 }
 
 // NewDay returns a new Day that solves part one and two for the given input
 func NewDay(input string) (*Day, error) {
 	lines := strings.Split(input, "\n")
 
-	valley, err := parseValley(lines)
+	day, err := parseValley(lines)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse valley: %w", err)
 	}
 
-	return &Day{
-		valley: valley,
-	}, nil
+	return &day, nil
 }
 
 // SolvePartOne solves part one
 func (d Day) SolvePartOne() (string, error) {
-	e := newExpedition(d.valley)
-	bfs(e)
-
-	return fmt.Sprintf("%d", bfs(e)), nil
+	return d.SolvePartOneContext(context.Background())
 }
 
 // SolvePartTwo solves part two
 func (d Day) SolvePartTwo() (string, error) {
-	return "", nil
+	return d.SolvePartTwoContext(context.Background())
 }
 
-func parseValley(lines []string) ([][]rune, error) {
-	var valley = make([][]rune, 0, len(lines))
-	for _, line := range lines {
-		valley = append(valley, []rune(line))
+// SolvePartOneContext solves part one, stopping the search when the context is done
+func (d Day) SolvePartOneContext(ctx context.Context) (string, error) {
+	minutes, err := d.cross(ctx, d.start, d.goal, 0)
+	if err != nil {
+		return "", fmt.Errorf("could not reach goal: %w", err)
 	}
-	return valley, nil
+
+	return fmt.Sprintf("%d", minutes), nil
 }
 
-func newExpedition(valley [][]rune) expedition {
-	start := 0
-	for valley[0][start] == wall {
-		start++
-	}
+// SolvePartTwoContext solves part two, stopping the search when the context is done
+func (d Day) SolvePartTwoContext(ctx context.Context) (string, error) {
+	trips := [][2]util.Point2{{d.start, d.goal}, {d.goal, d.start}, {d.start, d.goal}}
 
-	goal := 0
-	for valley[len(valley)-1][goal] == wall {
-		goal++
-	}
-
-	var blizzards []*blizzard
-	for i := 0; i < len(valley); i++ {
-		for j := 0; j < len(valley[0]); j++ {
-			if isBlizzard(valley[i][j]) {
-				b := blizzard{
-					direction: valley[i][j],
-					position:  position{i: i, j: j},
-				}
-				blizzards = append(blizzards, &b)
-			}
+	minutes := 0
+	for _, trip := range trips {
+		tripMinutes, err := d.cross(ctx, trip[0], trip[1], minutes)
+		if err != nil {
+			return "", fmt.Errorf("could not go from %v to %v: %w", trip[0], trip[1], err)
 		}
+		minutes += tripMinutes
 	}
 
-	return expedition{
-		valley:            valley,
-		blizzards:         blizzards,
-		current:           position{i: 0, j: start},
-		goal:              position{i: len(valley) - 1, j: goal},
-		minDistanceToGoal: position{i: 0, j: start}.manhattanDistance(position{i: len(valley) - 1, j: goal}),
-	}
+	return fmt.Sprintf("%d", minutes), nil
 }
 
-func bfs(e expedition) int {
-	expeditions := []expedition{e}
-
-	for len(expeditions) > 0 {
-		e, expeditions = expeditions[0], expeditions[1:]
-
-		blizzards := e.moveBlizzards()
-		blockedPositions := e.getBlockedPositions()
-		for _, nextPos := range e.availablePositions(blockedPositions) {
-			if e.goal == nextPos {
-				return e.minutes
-			}
-
-			distanceToGoal := e.current.manhattanDistance(e.goal)
-			if distanceToGoal > e.minDistanceToGoal+1 {
-				continue
-			}
-
-			e := expedition{
-				valley:            e.valley,
-				blizzards:         blizzards,
-				current:           nextPos,
-				goal:              e.goal,
-				minutes:           e.minutes + 1,
-				minDistanceToGoal: min(e.minDistanceToGoal, distanceToGoal),
-			}
-			expeditions = append(expeditions, e)
+func parseValley(lines []string) (Day, error) {
+	valley, err := parse.Grid(lines, func(r rune) (rune, error) {
+		if r != wall && r != ground && !isBlizzard(r) {
+			return 0, fmt.Errorf("invalid tile %q", r)
 		}
+		return r, nil
+	})
+	if err != nil {
+		return Day{}, err
 	}
-	panic("BUG! impossible to reach goal")
-}
-
-func (e expedition) moveBlizzards() []*blizzard {
-	blizzards := make([]*blizzard, 0, len(e.blizzards))
-	for _, b := range e.blizzards {
-		blizzards = append(blizzards, &blizzard{
-			direction: b.direction,
-			position:  b.move(e.valley),
-		})
+	if valley.Rows() < 3 || valley.Cols() < 3 {
+		return Day{}, fmt.Errorf("valley of %dx%d is too small", valley.Rows(), valley.Cols())
 	}
-	return blizzards
-}
 
-func (e expedition) getBlockedPositions() [][]bool {
-	var blockedPositions = make([][]bool, 0, len(e.valley))
-	for i := range len(e.valley) {
-		blockedPositions = append(blockedPositions, make([]bool, len(e.valley[i])))
+	start, ok := findGround(valley, 0)
+	if !ok {
+		return Day{}, &parse.Error{Line: 1, Err: fmt.Errorf("top row has no ground tile to start")}
 	}
-
-	for i := 0; i < len(e.valley); i++ {
-		for j := 0; j < len(e.valley[0]); j++ {
-			if e.valley[i][j] == wall {
-				blockedPositions[i][j] = true
-			}
-		}
-	}
-
-	for _, b := range e.blizzards {
-		blockedPositions[b.position.i][b.position.j] = true
+	goal, ok := findGround(valley, valley.Rows()-1)
+	if !ok {
+		return Day{}, &parse.Error{Line: valley.Rows(), Err: fmt.Errorf("bottom row has no ground tile to reach")}
 	}
-
-	return blockedPositions
+	return Day{valley: valley, start: start, goal: goal}, nil
 }
 
-func (e expedition) availablePositions(blockedPositions [][]bool) []position {
-	var availablePositions []position
-
-	down := position{i: e.current.i + 1, j: e.current.j}
-	if e.isValid(down) && !blockedPositions[down.i][down.j] {
-		availablePositions = append(availablePositions, down)
+// findGround returns the first ground tile of a row
+func findGround(valley util.Grid[rune], row int) (util.Point2, bool) {
+	for p, tile := range valley.Row(row) {
+		if tile == ground {
+			return p, true
+		}
 	}
+	return util.Point2{}, false
+}
 
-	wait := e.current
-	if e.isValid(wait) && !blockedPositions[wait.i][wait.j] {
-		availablePositions = append(availablePositions, wait)
+// cross returns the minutes needed to go from one position to another, leaving at the given minute
+func (d Day) cross(ctx context.Context, from, to util.Point2, minute int) (int, error) {
+	period := d.period()
+	neighbours := func(s state) []state {
+		next := (s.minute + 1) % period
+		var states []state
+		for _, move := range moves {
+			p := s.position.Add(move)
+			if tile, ok := d.valley.Get(p); ok && tile != wall && !d.hasBlizzard(p, next) {
+				states = append(states, state{position: p, minute: next})
+			}
+		}
+		return states
 	}
-
-	up := position{i: e.current.i - 1, j: e.current.j}
-	if e.isValid(up) && !blockedPositions[up.i][up.j] {
-		availablePositions = append(availablePositions, up)
+	isGoal := func(s state) bool {
+		return s.position == to
 	}
 
-	right := position{i: e.current.i, j: e.current.j + 1}
-	if e.isValid(right) && !blockedPositions[right.i][right.j] {
-		availablePositions = append(availablePositions, right)
-	}
-
-	left := position{i: e.current.i, j: e.current.j - 1}
-	if e.isValid(left) && !blockedPositions[left.i][left.j] {
-		availablePositions = append(availablePositions, left)
+	result, err := search.BFS(ctx, neighbours, isGoal, state{position: from, minute: minute % period})
+	if err != nil {
+		return 0, err
 	}
-
-	return availablePositions
+	return result.Cost, nil
 }
 
-func (e expedition) isValid(p position) bool {
-	rows := len(e.valley)
-	columns := len(e.valley[0])
-	return p.i >= 0 && p.i < rows && p.j >= 0 && p.j < columns
+// period returns the number of minutes after which all the blizzards are back to their initial position
+func (d Day) period() int {
+	// The product of both sizes is smaller than the number of tiles, so their LCM can't overflow
+	period, _ := util.LCM(d.valley.Rows()-2, d.valley.Cols()-2)
+	return period
 }
 
-func (b blizzard) move(valley [][]rune) position {
-	rows := len(valley)
-	columns := len(valley[0])
+// hasBlizzard returns true if a blizzard is at the given position at the given minute.
+// Instead of moving the blizzards, it looks for the blizzards that would reach the position at that minute.
+func (d Day) hasBlizzard(p util.Point2, minute int) bool {
+	height, width := d.valley.Rows()-2, d.valley.Cols()-2
+	// Blizzards only move inside the walls, so the row and column are relative to the inner area
+	row, col := p.Y-1, p.X-1
+	if row < 0 || row >= height || col < 0 || col >= width {
+		return false
+	}
 
-	switch b.direction {
-	case upBlizzard:
-		nextPos := position{i: (b.position.i - 1 + rows) % rows, j: b.position.j}
-		if valley[nextPos.i][nextPos.j] == wall {
-			b.position = nextPos
-			nextPos = b.move(valley)
-		}
-		return nextPos
-	case downBlizzard:
-		nextPos := position{i: (b.position.i + 1) % rows, j: b.position.j}
-		if valley[nextPos.i][nextPos.j] == wall {
-			b.position = nextPos
-			nextPos = b.move(valley)
-		}
-		return nextPos
-	case rightBlizzard:
-		nextPos := position{i: b.position.i, j: (b.position.j + 1) % columns}
-		if valley[nextPos.i][nextPos.j] == wall {
-			b.position = nextPos
-			nextPos = b.move(valley)
-		}
-		return nextPos
-	case leftBlizzard:
-		nextPos := position{i: b.position.i, j: (b.position.j - 1 + columns) % columns}
-		if valley[nextPos.i][nextPos.j] == wall {
-			b.position = nextPos
-			nextPos = b.move(valley)
-		}
-		return nextPos
+	wrap := func(x, n int) int {
+		return ((x%n)+n)%n + 1
 	}
-	panic("BUG! invalid blizzard direction")
+	return d.valley.At(util.Point2{X: wrap(col-minute, width), Y: p.Y}) == rightBlizzard ||
+		d.valley.At(util.Point2{X: wrap(col+minute, width), Y: p.Y}) == leftBlizzard ||
+		d.valley.At(util.Point2{X: p.X, Y: wrap(row-minute, height)}) == downBlizzard ||
+		d.valley.At(util.Point2{X: p.X, Y: wrap(row+minute, height)}) == upBlizzard
 }
 
 func isBlizzard(r rune) bool {
 	return r == upBlizzard || r == downBlizzard || r == rightBlizzard || r == leftBlizzard
 }
-
-func (p position) manhattanDistance(other position) int {
-	return util.Abs(p.i-other.i) + util.Abs(p.j-other.j)
-}
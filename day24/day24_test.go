@@ -1,24 +1,27 @@
 package day24
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
 )
 
 func TestNewDay(t *testing.T) {
-	expected := &Day{
-		valley: [][]rune{
-			{wall, ground, wall, wall, wall, wall, wall},
-			{wall, ground, ground, ground, ground, ground, wall},
-			{wall, rightBlizzard, ground, ground, ground, ground, wall},
-			{wall, ground, ground, ground, ground, ground, wall},
-			{wall, ground, ground, ground, downBlizzard, ground, wall},
-			{wall, ground, ground, ground, ground, ground, wall},
-			{wall, wall, wall, wall, wall, ground, wall},
-		},
-	}
+	valley, err := util.GridFromRows([][]rune{
+		{wall, ground, wall, wall, wall, wall, wall},
+		{wall, ground, ground, ground, ground, ground, wall},
+		{wall, rightBlizzard, ground, ground, ground, ground, wall},
+		{wall, ground, ground, ground, ground, ground, wall},
+		{wall, ground, ground, ground, downBlizzard, ground, wall},
+		{wall, ground, ground, ground, ground, ground, wall},
+		{wall, wall, wall, wall, wall, ground, wall},
+	})
+	require.NoError(t, err)
+	expected := &Day{valley: valley, start: util.Point2{X: 1, Y: 0}, goal: util.Point2{X: 5, Y: 6}}
 	input := `#.#####
 #.....#
 #>....#
@@ -32,6 +35,18 @@ func TestNewDay(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestNewDayShould(t *testing.T) {
+	t.Run("fail without start", func(t *testing.T) {
+		_, err := NewDay("#####\n#...#\n###.#")
+		assert.EqualError(t, err, "could not parse valley: line 1: top row has no ground tile to start")
+	})
+
+	t.Run("fail without goal", func(t *testing.T) {
+		_, err := NewDay("#.###\n#...#\n#####")
+		assert.EqualError(t, err, "could not parse valley: line 3: bottom row has no ground tile to reach")
+	})
+}
+
 func TestSolvePartOne(t *testing.T) {
 	input := `#.######
 #>>.<^<#
@@ -49,58 +64,73 @@ func TestSolvePartOne(t *testing.T) {
 }
 
 func TestSolvePartTwo(t *testing.T) {
-	day := &Day{}
+	input := `#.######
+#>>.<^<#
+#.<..<<#
+#>v.><>#
+#<^v^^>#
+######.#`
+	day, err := NewDay(input)
+	require.NoError(t, err)
 
 	answer, err := day.SolvePartTwo()
 	require.NoError(t, err)
 
-	assert.Equal(t, "", answer)
+	assert.Equal(t, "54", answer)
 }
 
-func TestBlizzardMoveShould(t *testing.T) {
-	valley := [][]rune{
-		{wall, ground, wall, wall, wall, wall, wall},
-		{wall, ground, ground, ground, ground, ground, wall},
-		{wall, ground, ground, ground, ground, ground, wall},
-		{wall, ground, ground, ground, ground, ground, wall},
-		{wall, ground, ground, ground, ground, ground, wall},
-		{wall, ground, ground, ground, ground, ground, wall},
-		{wall, wall, wall, wall, wall, ground, wall},
-	}
+func TestHasBlizzardShould(t *testing.T) {
+	input := `#.#####
+#.....#
+#>....#
+#.....#
+#...v.#
+#.....#
+#####.#`
+	day, err := NewDay(input)
+	require.NoError(t, err)
 
-	t.Run("move right blizzard one step to the right", func(t *testing.T) {
-		b := blizzard{
-			direction: rightBlizzard,
-			position:  position{i: 2, j: 1},
-		}
+	t.Run("find blizzards at their initial position", func(t *testing.T) {
+		assert.True(t, day.hasBlizzard(util.Point2{X: 1, Y: 2}, 0))
+		assert.True(t, day.hasBlizzard(util.Point2{X: 4, Y: 4}, 0))
+		assert.False(t, day.hasBlizzard(util.Point2{X: 2, Y: 2}, 0))
+	})
 
-		assert.Equal(t, position{i: 2, j: 2}, b.move(valley))
+	t.Run("find a right blizzard one step to the right", func(t *testing.T) {
+		assert.True(t, day.hasBlizzard(util.Point2{X: 2, Y: 2}, 1))
+		assert.False(t, day.hasBlizzard(util.Point2{X: 1, Y: 2}, 1))
 	})
 
-	t.Run("move down blizzard to the opposite side", func(t *testing.T) {
-		b := blizzard{
-			direction: downBlizzard,
-			position:  position{i: 5, j: 2},
-		}
+	t.Run("find a down blizzard on the opposite side", func(t *testing.T) {
+		assert.True(t, day.hasBlizzard(util.Point2{X: 4, Y: 1}, 2))
+	})
 
-		assert.Equal(t, position{i: 1, j: 2}, b.move(valley))
+	t.Run("find a right blizzard back at its position after a lap", func(t *testing.T) {
+		assert.True(t, day.hasBlizzard(util.Point2{X: 1, Y: 2}, 5))
 	})
 
-	t.Run("move down blizzard to the opposite side (beginning current)", func(t *testing.T) {
-		b := blizzard{
-			direction: downBlizzard,
-			position:  position{i: 5, j: 1},
+	t.Run("never find blizzards on the start and the goal", func(t *testing.T) {
+		for minute := range 10 {
+			assert.False(t, day.hasBlizzard(util.Point2{X: 1, Y: 0}, minute))
+			assert.False(t, day.hasBlizzard(util.Point2{X: 5, Y: 6}, minute))
 		}
-
-		assert.Equal(t, position{i: 0, j: 1}, b.move(valley))
 	})
+}
 
-	t.Run("move right blizzard to the opposite side", func(t *testing.T) {
-		b := blizzard{
-			direction: rightBlizzard,
-			position:  position{i: 1, j: 5},
-		}
+func TestSolveContextShould(t *testing.T) {
+	t.Run("stop part one when the context is done", func(t *testing.T) {
+		input := `#.######
+#>>.<^<#
+#.<..<<#
+#>v.><>#
+#<^v^^>#
+######.#`
+		day, err := NewDay(input)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 
-		assert.Equal(t, position{i: 1, j: 1}, b.move(valley))
+		_, err = day.SolvePartOneContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
 	})
 }
@@ -0,0 +1,31 @@
+package main
+
+// The days register themselves when their package is imported.
+// This file is generated by the new-day command, run it to add a new day.
+
+import (
+	_ "github.com/OctaviPascual/AdventOfCode2022/day01"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day02"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day03"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day04"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day05"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day06"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day07"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day08"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day09"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day10"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day11"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day12"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day13"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day14"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day15"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day16"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day17"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day18"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day19"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day20"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day21"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day22"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day23"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day24"
+)
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/OctaviPascual/AdventOfCode2022/inputs"
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+)
+
+// stdinPath is the input path that reads the puzzle input from the standard input
+const stdinPath = "-"
+
+// rootEnv is the environment variable holding the default root of the repository
+const rootEnv = "AOC_ROOT"
+
+// cacheEnv is the environment variable holding the default directory of the input cache
+const cacheEnv = "AOC_INPUT_CACHE"
+
+// inputReader reads the puzzle input of the days
+type inputReader struct {
+	// root is the directory that the default input paths of the days are relative to
+	root string
+	// path overrides the default input path of the day, it can be stdinPath
+	path  string
+	stdin io.Reader
+	// cache checks that the default inputs are intact, they are not checked if it's nil
+	cache *inputs.Cache
+	// refresh replaces the cached inputs with the current ones instead of checking them
+	refresh bool
+	// warnings receives the problems with the cache that don't make the input wrong, they are discarded if it's nil
+	warnings io.Writer
+
+	mu sync.Mutex
+	// hashes holds the hash of the input read for each day
+	hashes map[int]string
+}
+
+func (r *inputReader) read(day registry.Day) (string, error) {
+	var bytes []byte
+	var err error
+	switch r.path {
+	case stdinPath:
+		bytes, err = io.ReadAll(r.stdin)
+		if err != nil {
+			return "", fmt.Errorf("could not read stdin: %w", err)
+		}
+	case "":
+		bytes, err = readFile(filepath.Join(r.root, filepath.FromSlash(day.InputPath)))
+		if err == nil && r.cache != nil {
+			if err := r.checkCache(day, bytes); err != nil {
+				return "", fmt.Errorf("could not check input: %w", err)
+			}
+		}
+	default:
+		bytes, err = readFile(r.path)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hashes == nil {
+		r.hashes = make(map[int]string)
+	}
+	r.hashes[day.Number] = inputs.Hash(bytes)
+
+	input := string(bytes)
+	return strings.TrimSuffix(input, "\n"), nil
+}
+
+// checkCache checks the input of a day with the cache, or replaces the cached one when refreshing.
+// Only modified or truncated inputs are errors, a cache that can't be read or written is just a warning.
+func (r *inputReader) checkCache(day registry.Day, input []byte) error {
+	var err error
+	if r.refresh {
+		err = r.cache.Put(day.Number, input)
+	} else {
+		err = r.cache.Check(day.Number, input)
+	}
+	if err == nil || errors.Is(err, inputs.ErrModified) || errors.Is(err, inputs.ErrTruncated) {
+		return err
+	}
+
+	if r.warnings != nil {
+		fmt.Fprintf(r.warnings, "Warning: the input of day %d was not checked: %v\n", day.Number, err)
+	}
+	return nil
+}
+
+// hash returns the hash of the input read for a day, or an empty string if it was not read
+func (r *inputReader) hash(day int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hashes[day]
+}
+
+func readFile(path string) ([]byte, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %s: %w", path, err)
+	}
+	return bytes, nil
+}
+
+// defaultRoot returns the root of the repository given by the environment, or the working directory
+func defaultRoot() string {
+	if root := os.Getenv(rootEnv); root != "" {
+		return root
+	}
+	return "."
+}
+
+// defaultCache returns the directory of the input cache given by the environment, the cache is disabled if it's empty
+func defaultCache() string {
+	return os.Getenv(cacheEnv)
+}
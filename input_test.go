@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/inputs"
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+)
+
+func TestInputReaderShould(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "day01"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "day01", "day01.txt"), []byte("1000\n2000\n"), 0644))
+	day := registry.Day{Number: 1, InputPath: "day01/day01.txt"}
+
+	t.Run("read the input of the day relative to the root", func(t *testing.T) {
+		input, err := (&inputReader{root: root}).read(day)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1000\n2000", input)
+	})
+
+	t.Run("read the input from the given path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "other.txt")
+		require.NoError(t, os.WriteFile(path, []byte("3000\n"), 0644))
+
+		input, err := (&inputReader{root: root, path: path}).read(day)
+		require.NoError(t, err)
+
+		assert.Equal(t, "3000", input)
+	})
+
+	t.Run("read the input from stdin", func(t *testing.T) {
+		input, err := (&inputReader{root: root, path: stdinPath, stdin: strings.NewReader("4000\n5000\n")}).read(day)
+		require.NoError(t, err)
+
+		assert.Equal(t, "4000\n5000", input)
+	})
+
+	t.Run("fail if the file does not exist", func(t *testing.T) {
+		_, err := (&inputReader{root: t.TempDir()}).read(day)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("remember the hash of the input", func(t *testing.T) {
+		r := &inputReader{root: root}
+		_, err := r.read(day)
+		require.NoError(t, err)
+
+		assert.Equal(t, inputs.Hash([]byte("1000\n2000\n")), r.hash(1))
+		assert.Empty(t, r.hash(2))
+	})
+
+	t.Run("detect an input that was truncated since it was cached", func(t *testing.T) {
+		cache := &inputs.Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, []byte("1000\n2000\n3000\n")))
+
+		_, err := (&inputReader{root: root, cache: cache}).read(day)
+		assert.ErrorIs(t, err, inputs.ErrTruncated)
+	})
+
+	t.Run("replace the cached input when refreshing", func(t *testing.T) {
+		cache := &inputs.Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, []byte("1000\n2000\n3000\n")))
+
+		_, err := (&inputReader{root: root, cache: cache, refresh: true}).read(day)
+		require.NoError(t, err)
+
+		cached, err := cache.Get(1)
+		require.NoError(t, err)
+		assert.Equal(t, "1000\n2000\n", string(cached))
+	})
+
+	t.Run("only warn when the cache can't be written", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0644))
+		var warnings strings.Builder
+
+		input, err := (&inputReader{root: root, cache: &inputs.Cache{Dir: file}, warnings: &warnings}).read(day)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1000\n2000", input)
+		assert.Contains(t, warnings.String(), "the input of day 1 was not checked")
+	})
+
+	t.Run("not check inputs from other paths", func(t *testing.T) {
+		cache := &inputs.Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, []byte("1000\n2000\n3000\n")))
+
+		_, err := (&inputReader{root: root, path: stdinPath, stdin: strings.NewReader("4000"), cache: cache}).read(day)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,109 @@
+// Package inputs keeps a copy of the puzzle input of each day to detect when an input is modified or truncated.
+package inputs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrModified is returned when an input differs from the cached one
+	ErrModified = errors.New("input was modified")
+	// ErrTruncated is returned when an input is a prefix of the cached one, or doesn't end with a newline
+	ErrTruncated = errors.New("input is truncated")
+)
+
+// Hash returns the SHA-256 of an input, in hexadecimal
+func Hash(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+// Cache stores a read-only copy of the input of each day along with its hash.
+// Each day has its own files, so days can be checked concurrently.
+type Cache struct {
+	// Dir is the directory of the cache, it's created if it doesn't exist
+	Dir string
+}
+
+// Check compares the input of a day with the cached one, caching it if there is none yet.
+// Puzzle inputs always end with a newline, so an input without it is reported as truncated and not cached.
+func (c Cache) Check(day int, input []byte) error {
+	cached, err := c.Get(day)
+	if errors.Is(err, os.ErrNotExist) {
+		if !bytes.HasSuffix(input, []byte("\n")) {
+			return fmt.Errorf("%w: it doesn't end with a newline", ErrTruncated)
+		}
+		return c.Put(day, input)
+	}
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(input, cached) {
+		return nil
+	}
+	if bytes.HasPrefix(cached, input) {
+		missing := cached[len(input):]
+		if string(missing) == "\n" {
+			return fmt.Errorf("%w: it misses the trailing newline, the original is in %s", ErrTruncated, c.inputPath(day))
+		}
+		return fmt.Errorf("%w: it misses %d bytes and %d lines, the original is in %s",
+			ErrTruncated, len(missing), strings.Count(string(missing), "\n"), c.inputPath(day))
+	}
+	return fmt.Errorf("%w: its hash is %s instead of %s, the original is in %s", ErrModified, Hash(input), Hash(cached), c.inputPath(day))
+}
+
+// Get returns the cached input of a day, checking that it matches its hash
+func (c Cache) Get(day int) ([]byte, error) {
+	input, err := os.ReadFile(c.inputPath(day))
+	if err != nil {
+		return nil, fmt.Errorf("could not read cached input: %w", err)
+	}
+	hash, err := os.ReadFile(c.hashPath(day))
+	if err != nil {
+		return nil, fmt.Errorf("could not read cached hash: %w", err)
+	}
+
+	if Hash(input) != strings.TrimSpace(string(hash)) {
+		return nil, fmt.Errorf("%w: the cached input of day %d doesn't match its hash", ErrModified, day)
+	}
+	return input, nil
+}
+
+// Put caches the input of a day, replacing the previous one
+func (c Cache) Put(day int, input []byte) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("could not create directory %s: %w", c.Dir, err)
+	}
+
+	// The cached input is read-only, so it must be removed before being replaced
+	for _, path := range []string{c.inputPath(day), c.hashPath(day)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("could not remove %s: %w", path, err)
+		}
+	}
+
+	if err := os.WriteFile(c.inputPath(day), input, 0444); err != nil {
+		return fmt.Errorf("could not write file %s: %w", c.inputPath(day), err)
+	}
+	// The hash is written last, so an interrupted write is detected as a mismatch
+	if err := os.WriteFile(c.hashPath(day), []byte(Hash(input)+"\n"), 0444); err != nil {
+		return fmt.Errorf("could not write file %s: %w", c.hashPath(day), err)
+	}
+	return nil
+}
+
+func (c Cache) inputPath(day int) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("day%02d.txt", day))
+}
+
+func (c Cache) hashPath(day int) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("day%02d.sha256", day))
+}
@@ -0,0 +1,83 @@
+package inputs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashShould(t *testing.T) {
+	t.Run("return the SHA-256 of the input", func(t *testing.T) {
+		assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Hash(nil))
+	})
+}
+
+func TestCacheShould(t *testing.T) {
+	input := []byte("1000\n2000\n\n3000\n")
+
+	t.Run("cache the input the first time it's checked", func(t *testing.T) {
+		cache := Cache{Dir: filepath.Join(t.TempDir(), "inputs")}
+
+		require.NoError(t, cache.Check(1, input))
+		cached, err := cache.Get(1)
+		require.NoError(t, err)
+
+		assert.Equal(t, input, cached)
+		assert.NoError(t, cache.Check(1, input))
+	})
+
+	t.Run("detect a missing trailing newline", func(t *testing.T) {
+		cache := Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, input))
+
+		err := cache.Check(1, input[:len(input)-1])
+		assert.ErrorIs(t, err, ErrTruncated)
+		assert.ErrorContains(t, err, "it misses the trailing newline")
+	})
+
+	t.Run("detect missing lines", func(t *testing.T) {
+		cache := Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, input))
+
+		err := cache.Check(1, input[:10])
+		assert.ErrorIs(t, err, ErrTruncated)
+		assert.ErrorContains(t, err, "it misses 6 bytes and 2 lines")
+	})
+
+	t.Run("detect a modified input", func(t *testing.T) {
+		cache := Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, input))
+
+		assert.ErrorIs(t, cache.Check(1, []byte("1000\n2001\n\n3000\n")), ErrModified)
+	})
+
+	t.Run("not cache an input without a trailing newline", func(t *testing.T) {
+		cache := Cache{Dir: t.TempDir()}
+
+		assert.ErrorIs(t, cache.Check(1, []byte("1000")), ErrTruncated)
+		_, err := cache.Get(1)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("detect a cached input that doesn't match its hash", func(t *testing.T) {
+		cache := Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, input))
+		path := filepath.Join(cache.Dir, "day01.txt")
+		require.NoError(t, os.Chmod(path, 0644))
+		require.NoError(t, os.WriteFile(path, []byte("1000\n"), 0644))
+
+		_, err := cache.Get(1)
+		assert.ErrorIs(t, err, ErrModified)
+	})
+
+	t.Run("replace the cached input", func(t *testing.T) {
+		cache := Cache{Dir: t.TempDir()}
+		require.NoError(t, cache.Put(1, input))
+		require.NoError(t, cache.Put(1, []byte("4000\n")))
+
+		assert.NoError(t, cache.Check(1, []byte("4000\n")))
+	})
+}
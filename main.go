@@ -1,229 +1,241 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
 
-	"github.com/OctaviPascual/AdventOfCode2022/day01"
-	"github.com/OctaviPascual/AdventOfCode2022/day02"
-	"github.com/OctaviPascual/AdventOfCode2022/day03"
-	"github.com/OctaviPascual/AdventOfCode2022/day04"
-	"github.com/OctaviPascual/AdventOfCode2022/day05"
-	"github.com/OctaviPascual/AdventOfCode2022/day06"
-	"github.com/OctaviPascual/AdventOfCode2022/day07"
-	"github.com/OctaviPascual/AdventOfCode2022/day08"
-	"github.com/OctaviPascual/AdventOfCode2022/day09"
-	"github.com/OctaviPascual/AdventOfCode2022/day10"
-	"github.com/OctaviPascual/AdventOfCode2022/day11"
-	"github.com/OctaviPascual/AdventOfCode2022/day12"
-	"github.com/OctaviPascual/AdventOfCode2022/day13"
-	"github.com/OctaviPascual/AdventOfCode2022/day14"
-	"github.com/OctaviPascual/AdventOfCode2022/day15"
-	"github.com/OctaviPascual/AdventOfCode2022/day16"
-	"github.com/OctaviPascual/AdventOfCode2022/day17"
-	"github.com/OctaviPascual/AdventOfCode2022/day18"
-	"github.com/OctaviPascual/AdventOfCode2022/day19"
-	"github.com/OctaviPascual/AdventOfCode2022/day20"
-	"github.com/OctaviPascual/AdventOfCode2022/day21"
-	"github.com/OctaviPascual/AdventOfCode2022/day22"
-	"github.com/OctaviPascual/AdventOfCode2022/day23"
-	"github.com/OctaviPascual/AdventOfCode2022/day24"
+	"github.com/OctaviPascual/AdventOfCode2022/answers"
+	"github.com/OctaviPascual/AdventOfCode2022/inputs"
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+	"github.com/OctaviPascual/AdventOfCode2022/runner"
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/workspace"
 )
 
-// Day is the interface that wraps SolvePartOne and SolvePartTwo methods
-type Day interface {
-	SolvePartOne() (string, error)
-	SolvePartTwo() (string, error)
+const usage = `Usage: go run . [command] [options]
+
+Commands:
+  run     solve the selected days and print their answers (default)
+  verify  solve the selected days and compare their answers with the answers file
+  record  solve the selected days and write their answers to the answers file
+  new-day create the package of a new day with its input
+
+Run "go run . [command] --help" to see the options of a command.
+`
+
+func main() {
+	command, args := "run", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "run", "verify", "record":
+	case "help":
+		fmt.Print(usage)
+		return
+	case "new-day":
+		newDay(args)
+		return
+	default:
+		log.Fatalf("unknown command %q\n%s", command, usage)
+	}
+
+	opts, err := parseRunOptions(command, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("could not parse %s options: %v", command, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var summary runner.Summary
+	switch command {
+	case "run":
+		summary = run(ctx, opts, nil)
+	case "verify":
+		summary = verify(ctx, opts)
+	case "record":
+		summary = record(ctx, opts)
+	}
+
+	if err := summary.Write(opts.info()); err != nil {
+		log.Fatalf("could not write summary: %v", err)
+	}
+	if !summary.OK() {
+		stop()
+		os.Exit(1)
+	}
 }
 
-var days = []struct {
-	filename    string
-	constructor func(input string) (Day, error)
-}{
-	{
-		filename: "./day01/day01.txt",
-		constructor: func(input string) (Day, error) {
-			return day01.NewDay(input)
-		},
-	},
-	{
-		filename: "./day02/day02.txt",
-		constructor: func(input string) (Day, error) {
-			return day02.NewDay(input)
-		},
-	},
-	{
-		filename: "./day03/day03.txt",
-		constructor: func(input string) (Day, error) {
-			return day03.NewDay(input)
-		},
-	},
-	{
-		filename: "./day04/day04.txt",
-		constructor: func(input string) (Day, error) {
-			return day04.NewDay(input)
-		},
-	},
-	{
-		filename: "./day05/day05.txt",
-		constructor: func(input string) (Day, error) {
-			return day05.NewDay(input)
-		},
-	},
-	{
-		filename: "./day06/day06.txt",
-		constructor: func(input string) (Day, error) {
-			return day06.NewDay(input)
-		},
-	},
-	{
-		filename: "./day07/day07.txt",
-		constructor: func(input string) (Day, error) {
-			return day07.NewDay(input)
-		},
-	},
-	{
-		filename: "./day08/day08.txt",
-		constructor: func(input string) (Day, error) {
-			return day08.NewDay(input)
-		},
-	},
-	{
-		filename: "./day09/day09.txt",
-		constructor: func(input string) (Day, error) {
-			return day09.NewDay(input)
-		},
-	},
-	{
-		filename: "./day10/day10.txt",
-		constructor: func(input string) (Day, error) {
-			return day10.NewDay(input)
-		},
-	},
-	{
-		filename: "./day11/day11.txt",
-		constructor: func(input string) (Day, error) {
-			return day11.NewDay(input)
-		},
-	},
-	{
-		filename: "./day12/day12.txt",
-		constructor: func(input string) (Day, error) {
-			return day12.NewDay(input)
-		},
-	},
-	{
-		filename: "./day13/day13.txt",
-		constructor: func(input string) (Day, error) {
-			return day13.NewDay(input)
-		},
-	},
-	{
-		filename: "./day14/day14.txt",
-		constructor: func(input string) (Day, error) {
-			return day14.NewDay(input)
-		},
-	},
-	{
-		filename: "./day15/day15.txt",
-		constructor: func(input string) (Day, error) {
-			return day15.NewDay(input)
-		},
-	},
-	{
-		filename: "./day16/day16.txt",
-		constructor: func(input string) (Day, error) {
-			return day16.NewDay(input)
-		},
-	},
-	{
-		filename: "./day17/day17.txt",
-		constructor: func(input string) (Day, error) {
-			return day17.NewDay(input)
-		},
-	},
-	{
-		filename: "./day18/day18.txt",
-		constructor: func(input string) (Day, error) {
-			return day18.NewDay(input)
-		},
-	},
-	{
-		filename: "./day19/day19.txt",
-		constructor: func(input string) (Day, error) {
-			return day19.NewDay(input)
-		},
-	},
-	{
-		filename: "./day20/day20.txt",
-		constructor: func(input string) (Day, error) {
-			return day20.NewDay(input)
-		},
-	},
-	{
-		filename: "./day21/day21.txt",
-		constructor: func(input string) (Day, error) {
-			return day21.NewDay(input)
-		},
-	},
-	{
-		filename: "./day22/day22.txt",
-		constructor: func(input string) (Day, error) {
-			return day22.NewDay(input)
-		},
-	},
-	{
-		filename: "./day23/day23.txt",
-		constructor: func(input string) (Day, error) {
-			return day23.NewDay(input)
-		},
-	},
-	{
-		filename: "./day24/day24.txt",
-		constructor: func(input string) (Day, error) {
-			return day24.NewDay(input)
-		},
-	},
+// newDay creates the package of a new day from the templates of the workspace
+func newDay(args []string) {
+	opts, err := parseNewDayOptions(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("could not parse new-day options: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := (workspace.Workspace{Root: opts.root}).NewDay(ctx, opts.day, opts.fetcher); err != nil {
+		log.Fatalf("could not create day %d: %v", opts.day.Number, err)
+	}
+	fmt.Printf("Day %d created in %s\n", opts.day.Number, filepath.Join(opts.root, opts.day.Package()))
 }
 
-func main() {
-	for i, day := range days {
-		fmt.Printf("\nRunning day %d\n", i+1)
+// entryHook completes the entry of a part that has been solved before it's written
+type entryHook func(e *runner.Entry)
 
-		if i+1 == 19 {
-			fmt.Printf("[DISABLED] It takes ~5min to run\n")
-			continue
+// verify solves the selected days and compares their answers with the known ones, which fail the run if they differ
+func verify(ctx context.Context, opts runOptions) runner.Summary {
+	known, err := answers.Load(opts.answersPath)
+	if err != nil {
+		log.Fatalf("could not load answers: %v", err)
+	}
+
+	verdicts := make(map[answers.Verdict]int)
+	summary := run(ctx, opts, func(e *runner.Entry) {
+		verdict := known.Check(e.Day.Number, e.Part, e.InputHash, e.Answer)
+		verdicts[verdict]++
+
+		e.Verdict = verdict.String()
+		if verdict == answers.Fail {
+			e.Expected, _ = known.Get(e.Day.Number, e.Part)
 		}
+	})
 
-		if i+1 == 24 {
-			fmt.Printf("[DISABLED] Didn't manage to solve it\n")
-			continue
+	fmt.Fprintf(opts.info(), "\n%d passed, %d failed, %d missing\n", verdicts[answers.Pass], verdicts[answers.Fail], verdicts[answers.Missing])
+	return summary
+}
+
+// record solves the selected days and stores their answers, keeping the ones of the days that were not run
+func record(ctx context.Context, opts runOptions) runner.Summary {
+	known, err := answers.Load(opts.answersPath)
+	if errors.Is(err, os.ErrNotExist) {
+		known = answers.Answers{}
+	} else if err != nil {
+		log.Fatalf("could not load answers: %v", err)
+	}
+
+	summary := run(ctx, opts, func(e *runner.Entry) {
+		// An empty answer means that the part is not solved yet
+		if e.Answer != "" {
+			known.Set(e.Day.Number, e.Part, e.InputHash, e.Answer)
 		}
+	})
+
+	if err := known.Save(opts.answersPath); err != nil {
+		log.Fatalf("could not save answers: %v", err)
+	}
+	fmt.Fprintf(opts.info(), "\nAnswers written to %s\n", opts.answersPath)
+	return summary
+}
 
-		bytes, err := os.ReadFile(day.filename)
-		if err != nil {
-			log.Fatalf("could not read file %s: %v", day.filename, err)
+// run solves the selected days and writes their answers.
+// A day that fails doesn't stop the others, its errors are written with the answers and counted in the summary.
+func run(ctx context.Context, opts runOptions, hook entryHook) runner.Summary {
+	util.DefaultArithmetic = util.Arithmetic{Checked: opts.checked}
+
+	var selected, enabled []registry.Day
+	for _, day := range registry.Days() {
+		if !opts.selects(day.Number) {
+			continue
+		}
+		selected = append(selected, day)
+		if !day.Disabled() || opts.includeDisabled {
+			enabled = append(enabled, day)
 		}
-		input := string(bytes)
-		input = strings.TrimSuffix(input, "\n")
+	}
+
+	reader := &inputReader{root: opts.root, path: opts.input, stdin: os.Stdin, refresh: opts.refreshCache, warnings: os.Stderr}
+	if opts.cache != "" {
+		reader.cache = &inputs.Cache{Dir: opts.cache}
+	}
 
-		day, err := day.constructor(input)
-		if err != nil {
-			log.Fatalf("could not create day %d: %v", i+1, err)
+	results := runner.Run(ctx, enabled, runner.Options{
+		Workers:   opts.workers,
+		Parts:     opts.parts(),
+		ReadInput: reader.read,
+		Timeout:   opts.timeout,
+		// measuring memory forces garbage collections that slow down the days, so it's only done for the stats
+		MeasureMemory: opts.statsFormat != "",
+		Explain:       opts.explain,
+	})
+
+	out, err := runner.NewOutputWriter(os.Stdout, opts.output)
+	if err != nil {
+		log.Fatalf("could not create output: %v", err)
+	}
+
+	var summary runner.Summary
+	var collected []runner.Result
+	for _, day := range selected {
+		if day.Disabled() && !opts.includeDisabled {
+			reason := day.Note
+			if reason == "" {
+				reason = fmt.Sprintf("It is %s", day.Status)
+			}
+			skipped := runner.Entry{Day: day, Skipped: reason}
+			summary.Add(skipped)
+			if err := out.Write(skipped); err != nil {
+				log.Fatalf("could not write output: %v", err)
+			}
+			continue
 		}
 
-		answer, err := day.SolvePartOne()
-		if err != nil {
-			log.Fatalf("could not solve part one for day %d: %v", i+1, err)
+		result := <-results
+		collected = append(collected, result)
+		for _, e := range runner.EntriesOf(result) {
+			e.InputHash = reader.hash(day.Number)
+			if hook != nil && e.Err == nil {
+				hook(&e)
+			}
+			summary.Add(e)
+			if err := out.Write(e); err != nil {
+				log.Fatalf("could not write output: %v", err)
+			}
 		}
-		fmt.Printf("Part One: %s\n", answer)
+	}
+
+	if err := out.Close(); err != nil {
+		log.Fatalf("could not write output: %v", err)
+	}
 
-		answer, err = day.SolvePartTwo()
-		if err != nil {
-			log.Fatalf("could not solve part two for day %d: %v", i+1, err)
+	if opts.statsFormat != "" {
+		if err := writeStats(opts, runner.Stats(collected)); err != nil {
+			log.Fatalf("could not write stats: %v", err)
 		}
-		fmt.Printf("Part Two: %s\n", answer)
 	}
+	return summary
+}
+
+func writeStats(opts runOptions, stats []runner.Stat) error {
+	if opts.statsFile == "" {
+		fmt.Fprintln(opts.info())
+		return runner.WriteStats(opts.info(), opts.statsFormat, stats)
+	}
+
+	f, err := os.Create(opts.statsFile)
+	if err != nil {
+		return fmt.Errorf("could not create file %s: %w", opts.statsFile, err)
+	}
+	if err := runner.WriteStats(f, opts.statsFormat, stats); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
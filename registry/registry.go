@@ -0,0 +1,169 @@
+// Package registry keeps track of all the days that have been implemented.
+// Each day registers itself from an init function, so importing a day package is enough to make it available.
+package registry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Solver is the interface that wraps SolvePartOne and SolvePartTwo methods
+type Solver interface {
+	SolvePartOne() (string, error)
+	SolvePartTwo() (string, error)
+}
+
+// Status represents how far a day has been solved
+type Status int
+
+const (
+	// Unsolved means that at least one of the parts is not solved yet
+	Unsolved Status = iota
+	// Solved means that both parts are solved in a reasonable time
+	Solved
+	// Slow means that both parts are solved but it takes too long to run them
+	Slow
+)
+
+// String returns the name of the status
+func (s Status) String() string {
+	switch s {
+	case Unsolved:
+		return "unsolved"
+	case Solved:
+		return "solved"
+	case Slow:
+		return "slow"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Day holds the metadata of a day and how to build its solver
+type Day struct {
+	Number int
+	Title  string
+	// InputPath is the path to the puzzle input, relative to the root of the repository
+	InputPath string
+	Status    Status
+	// Note explains the status, for instance why a day is slow
+	Note string
+	New  func(input string) (Solver, error)
+}
+
+// Disabled returns true if the day should not be run by default
+func (d Day) Disabled() bool {
+	return d.Status != Solved
+}
+
+var (
+	mu   sync.RWMutex
+	days = make(map[int]Day)
+)
+
+// Register makes a day available in the registry.
+// It panics if the day is invalid or if it has already been registered.
+func Register(day Day) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if day.Number <= 0 {
+		panic(fmt.Sprintf("registry: invalid day number %d", day.Number))
+	}
+	if day.New == nil {
+		panic(fmt.Sprintf("registry: day %d has no constructor", day.Number))
+	}
+	if _, ok := days[day.Number]; ok {
+		panic(fmt.Sprintf("registry: day %d registered twice", day.Number))
+	}
+	days[day.Number] = day
+}
+
+// Get returns the day with the given number and whether it was found
+func Get(number int) (Day, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	day, ok := days[number]
+	return day, ok
+}
+
+// Days returns all the registered days sorted by number
+func Days() []Day {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	result := make([]Day, 0, len(days))
+	for _, day := range days {
+		result = append(result, day)
+	}
+	slices.SortFunc(result, func(a, b Day) int { return cmp.Compare(a.Number, b.Number) })
+	return result
+}
+
+// ContextSolver is the interface implemented by days whose solutions can be cancelled through a context
+type ContextSolver interface {
+	SolvePartOneContext(ctx context.Context) (string, error)
+	SolvePartTwoContext(ctx context.Context) (string, error)
+}
+
+// Explainer is the interface implemented by days that can explain how they reach their answers,
+// such as the schedule behind the pressure released in day 16
+type Explainer interface {
+	ExplainPartOneContext(ctx context.Context) (answer, explanation string, err error)
+	ExplainPartTwoContext(ctx context.Context) (answer, explanation string, err error)
+}
+
+// WithContext returns a ContextSolver for the given solver.
+// If the solver doesn't implement ContextSolver, each part is solved in its own goroutine and the call returns
+// as soon as the context is done, although the goroutine keeps running in the background until the part is solved.
+func WithContext(solver Solver) ContextSolver {
+	if contextSolver, ok := solver.(ContextSolver); ok {
+		return contextSolver
+	}
+	return contextAdapter{solver: solver}
+}
+
+type contextAdapter struct {
+	solver Solver
+}
+
+type answer struct {
+	value string
+	err   error
+}
+
+func (a contextAdapter) SolvePartOneContext(ctx context.Context) (string, error) {
+	return solveWithContext(ctx, a.solver.SolvePartOne)
+}
+
+func (a contextAdapter) SolvePartTwoContext(ctx context.Context) (string, error) {
+	return solveWithContext(ctx, a.solver.SolvePartTwo)
+}
+
+func solveWithContext(ctx context.Context, solve func() (string, error)) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	answers := make(chan answer, 1)
+	go func() {
+		// A panic in this goroutine can't be recovered by the caller, so it's returned as an error
+		defer func() {
+			if r := recover(); r != nil {
+				answers <- answer{err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+		value, err := solve()
+		answers <- answer{value: value, err: err}
+	}()
+
+	select {
+	case a := <-answers:
+		return a.value, a.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
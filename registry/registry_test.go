@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSolver struct{}
+
+func (f fakeSolver) SolvePartOne() (string, error) {
+	return "1", nil
+}
+
+func (f fakeSolver) SolvePartTwo() (string, error) {
+	return "2", nil
+}
+
+type slowSolver struct{}
+
+func (s slowSolver) SolvePartOne() (string, error) {
+	time.Sleep(time.Second)
+	return "1", nil
+}
+
+func (s slowSolver) SolvePartTwo() (string, error) {
+	return s.SolvePartOne()
+}
+
+type panicSolver struct{}
+
+func (p panicSolver) SolvePartOne() (string, error) {
+	panic("index out of range")
+}
+
+func (p panicSolver) SolvePartTwo() (string, error) {
+	return p.SolvePartOne()
+}
+
+type fakeContextSolver struct {
+	fakeSolver
+}
+
+func (f fakeContextSolver) SolvePartOneContext(ctx context.Context) (string, error) {
+	return "context 1", ctx.Err()
+}
+
+func (f fakeContextSolver) SolvePartTwoContext(ctx context.Context) (string, error) {
+	return "context 2", ctx.Err()
+}
+
+func newFakeSolver(string) (Solver, error) {
+	return fakeSolver{}, nil
+}
+
+func TestRegistryShould(t *testing.T) {
+	t.Run("return registered days sorted by number", func(t *testing.T) {
+		Register(Day{Number: 102, Title: "Second", Status: Solved, New: newFakeSolver})
+		Register(Day{Number: 101, Title: "First", Status: Slow, New: newFakeSolver})
+
+		var numbers []int
+		for _, day := range Days() {
+			numbers = append(numbers, day.Number)
+		}
+		assert.Subset(t, numbers, []int{101, 102})
+		assert.IsIncreasing(t, numbers)
+
+		day, ok := Get(101)
+		assert.True(t, ok)
+		assert.Equal(t, "First", day.Title)
+		assert.True(t, day.Disabled())
+	})
+
+	t.Run("not find unregistered days", func(t *testing.T) {
+		_, ok := Get(200)
+		assert.False(t, ok)
+	})
+
+	t.Run("panic when registering a day twice", func(t *testing.T) {
+		Register(Day{Number: 103, New: newFakeSolver})
+
+		assert.Panics(t, func() { Register(Day{Number: 103, New: newFakeSolver}) })
+	})
+
+	t.Run("panic when registering an invalid day", func(t *testing.T) {
+		assert.Panics(t, func() { Register(Day{Number: 0, New: newFakeSolver}) })
+		assert.Panics(t, func() { Register(Day{Number: 104}) })
+	})
+}
+
+func TestStatusShould(t *testing.T) {
+	t.Run("have a name", func(t *testing.T) {
+		assert.Equal(t, "unsolved", Unsolved.String())
+		assert.Equal(t, "solved", Solved.String())
+		assert.Equal(t, "slow", Slow.String())
+	})
+
+	t.Run("only enable solved days", func(t *testing.T) {
+		assert.True(t, Day{Status: Unsolved}.Disabled())
+		assert.False(t, Day{Status: Solved}.Disabled())
+		assert.True(t, Day{Status: Slow}.Disabled())
+	})
+}
+
+func TestWithContextShould(t *testing.T) {
+	t.Run("solve parts of solvers without context", func(t *testing.T) {
+		solver := WithContext(fakeSolver{})
+
+		answer, err := solver.SolvePartOneContext(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "1", answer)
+
+		answer, err = solver.SolvePartTwoContext(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "2", answer)
+	})
+
+	t.Run("stop waiting for solvers without context when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := WithContext(slowSolver{}).SolvePartOneContext(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("use the solvers that support context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		answer, err := WithContext(fakeContextSolver{}).SolvePartTwoContext(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, "context 2", answer)
+	})
+	t.Run("return panics of solvers without context as errors", func(t *testing.T) {
+		_, err := WithContext(panicSolver{}).SolvePartOneContext(context.Background())
+
+		assert.EqualError(t, err, "panic: index out of range")
+	})
+}
@@ -0,0 +1,98 @@
+package runner
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+// samplingPeriod is how often the heap size is sampled to find its peak
+const samplingPeriod = time.Millisecond
+
+const (
+	allocsMetric     = "/gc/heap/allocs:objects"
+	allocBytesMetric = "/gc/heap/allocs:bytes"
+	heapMetric       = "/memory/classes/heap/objects:bytes"
+)
+
+// Measurement holds the resources used while running a function.
+// Memory figures are process-wide, so they are only accurate when nothing else runs at the same time.
+type Measurement struct {
+	Duration time.Duration
+	// Allocs is the number of heap allocations
+	Allocs uint64
+	// AllocBytes is the number of bytes allocated in the heap
+	AllocBytes uint64
+	// PeakHeap is the largest heap size observed, in bytes
+	PeakHeap uint64
+}
+
+// measureTime measures only the time taken to run f, leaving the memory figures empty
+func measureTime(f func()) Measurement {
+	start := time.Now()
+	f()
+	return Measurement{Duration: time.Since(start)}
+}
+
+// measureMemory measures the time and memory used to run f.
+// It forces two garbage collections, which stop every other goroutine, so it's only used when stats are reported.
+func measureMemory(f func()) Measurement {
+	// Allocations are only accounted once the per-processor caches are flushed, which a garbage collection forces
+	runtime.GC()
+	before := readMemory()
+	peak := before.heap
+
+	done := make(chan struct{})
+	sampled := make(chan uint64)
+	go func() {
+		samplePeak := uint64(0)
+		ticker := time.NewTicker(samplingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				samplePeak = max(samplePeak, readMemory().heap)
+			case <-done:
+				sampled <- samplePeak
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	f()
+	duration := time.Since(start)
+
+	close(done)
+	peak = max(peak, <-sampled, readMemory().heap)
+	runtime.GC()
+	after := readMemory()
+
+	return Measurement{
+		Duration:   duration,
+		Allocs:     after.allocs - before.allocs,
+		AllocBytes: after.allocBytes - before.allocBytes,
+		PeakHeap:   peak,
+	}
+}
+
+type memory struct {
+	allocs     uint64
+	allocBytes uint64
+	heap       uint64
+}
+
+func readMemory() memory {
+	samples := []metrics.Sample{
+		{Name: allocsMetric},
+		{Name: allocBytesMetric},
+		{Name: heapMetric},
+	}
+	metrics.Read(samples)
+
+	return memory{
+		allocs:     samples[0].Value.Uint64(),
+		allocBytes: samples[1].Value.Uint64(),
+		heap:       samples[2].Value.Uint64(),
+	}
+}
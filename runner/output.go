@@ -0,0 +1,354 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+)
+
+// OutputFormats holds the formats supported by NewOutputWriter
+var OutputFormats = []string{"text", "json", "tap", "markdown"}
+
+// Entry holds what is written about a part of a day.
+// Entries about the whole day, such as a skipped day or an input that can't be read, have Part set to 0.
+type Entry struct {
+	Day    registry.Day
+	Part   int
+	Answer string
+	// Explanation holds how the answer was reached, it's empty if the day was not explained
+	Explanation string
+	Duration    time.Duration
+	Err         error
+	// InputHash holds the hash of the input the part was solved for
+	InputHash string
+	// Skipped holds the reason why the day was not run
+	Skipped string
+	// Verdict holds the result of verifying the answer, it's empty if the answer was not verified
+	Verdict string
+	// Expected holds the known answer when the verification fails
+	Expected string
+}
+
+// OutputWriter writes entries as they are produced, entries of the same day must be written one after the other
+type OutputWriter interface {
+	Write(e Entry) error
+	// Close writes anything that is pending, it doesn't close the underlying writer
+	Close() error
+}
+
+// NewOutputWriter returns a writer for the given format, which must be one of OutputFormats
+func NewOutputWriter(w io.Writer, format string) (OutputWriter, error) {
+	switch format {
+	case "text":
+		return &textWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w}, nil
+	case "tap":
+		return &tapWriter{w: w}, nil
+	case "markdown":
+		return &markdownWriter{w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
+
+// EntriesOf returns the entries of the parts of a result
+func EntriesOf(result Result) []Entry {
+	if result.Err != nil {
+		return []Entry{{Day: result.Day, Err: result.Err}}
+	}
+
+	entries := make([]Entry, 0, len(result.Parts))
+	for _, part := range result.Parts {
+		entries = append(entries, Entry{
+			Day:         result.Day,
+			Part:        part.Part,
+			Answer:      part.Answer,
+			Explanation: part.Explanation,
+			Duration:    part.Solve.Duration,
+			Err:         part.Err,
+		})
+	}
+	return entries
+}
+
+type textWriter struct {
+	w       io.Writer
+	lastDay int
+}
+
+func (t *textWriter) Write(e Entry) error {
+	if e.Day.Number != t.lastDay {
+		t.lastDay = e.Day.Number
+		if _, err := fmt.Fprintf(t.w, "\nRunning day %d: %s\n", e.Day.Number, e.Day.Title); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	switch {
+	case e.Skipped != "":
+		_, err = fmt.Fprintf(t.w, "[DISABLED] %s\n", e.Skipped)
+	case e.Part == 0:
+		_, err = fmt.Fprintf(t.w, "[ERROR] %v\n", e.Err)
+	case e.Err != nil:
+		_, err = fmt.Fprintf(t.w, "[ERROR] Part %s: %v\n", PartName(e.Part), e.Err)
+	default:
+		verdict := ""
+		if e.Verdict != "" {
+			verdict = "[" + e.Verdict + "] "
+		}
+		_, err = fmt.Fprintf(t.w, "%sPart %s: %s\n", verdict, PartName(e.Part), e.Answer)
+		if err == nil && e.Expected != "" {
+			_, err = fmt.Fprintf(t.w, "Expected: %s\n", e.Expected)
+		}
+		if err == nil && e.Explanation != "" {
+			_, err = fmt.Fprintf(t.w, "%s\n", e.Explanation)
+		}
+	}
+	return err
+}
+
+func (t *textWriter) Close() error {
+	return nil
+}
+
+type jsonEntry struct {
+	Day         int    `json:"day"`
+	Title       string `json:"title"`
+	Part        int    `json:"part,omitempty"`
+	Answer      string `json:"answer,omitempty"`
+	Explanation string `json:"explanation,omitempty"`
+	DurationNs  int64  `json:"duration_ns,omitempty"`
+	Error       string `json:"error,omitempty"`
+	InputHash   string `json:"input_hash,omitempty"`
+	Skipped     string `json:"skipped,omitempty"`
+	Verdict     string `json:"verdict,omitempty"`
+	Expected    string `json:"expected,omitempty"`
+}
+
+// jsonWriter writes all the entries in a single JSON array once it's closed
+type jsonWriter struct {
+	w       io.Writer
+	entries []jsonEntry
+}
+
+func (j *jsonWriter) Write(e Entry) error {
+	entry := jsonEntry{
+		Day:         e.Day.Number,
+		Title:       e.Day.Title,
+		Part:        e.Part,
+		Answer:      e.Answer,
+		Explanation: e.Explanation,
+		DurationNs:  e.Duration.Nanoseconds(),
+		InputHash:   e.InputHash,
+		Skipped:     e.Skipped,
+		Verdict:     e.Verdict,
+		Expected:    e.Expected,
+	}
+	if e.Err != nil {
+		entry.Error = e.Err.Error()
+	}
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	entries := j.entries
+	if entries == nil {
+		entries = []jsonEntry{}
+	}
+	encoder := json.NewEncoder(j.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+// tapWriter writes entries following the Test Anything Protocol, version 13
+type tapWriter struct {
+	w     io.Writer
+	tests int
+}
+
+func (t *tapWriter) Write(e Entry) error {
+	if t.tests == 0 {
+		if _, err := fmt.Fprintln(t.w, "TAP version 13"); err != nil {
+			return err
+		}
+	}
+	t.tests++
+
+	description := fmt.Sprintf("day %d", e.Day.Number)
+	if e.Part != 0 {
+		description += " part " + strings.ToLower(PartName(e.Part))
+	}
+
+	status := "ok"
+	if e.Err != nil || e.Verdict == "FAIL" {
+		status = "not ok"
+	}
+
+	var diagnostics [][2]string
+	switch {
+	case e.Skipped != "":
+		description += " # SKIP " + escapeTAP(e.Skipped)
+	case e.Err != nil:
+		diagnostics = append(diagnostics, [2]string{"message", e.Err.Error()})
+	case strings.Contains(e.Answer, "\n"):
+		diagnostics = append(diagnostics, [2]string{"answer", e.Answer})
+	default:
+		description += ": " + escapeTAP(e.Answer)
+	}
+	if e.Verdict != "" && e.Verdict != "PASS" {
+		diagnostics = append(diagnostics, [2]string{"verdict", e.Verdict})
+	}
+	if e.Expected != "" {
+		diagnostics = append(diagnostics, [2]string{"expected", e.Expected})
+	}
+
+	if _, err := fmt.Fprintf(t.w, "%s %d - %s\n", status, t.tests, description); err != nil {
+		return err
+	}
+	if len(diagnostics) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("  ---\n")
+	for _, d := range diagnostics {
+		writeYAMLField(&b, d[0], d[1])
+	}
+	b.WriteString("  ...\n")
+	_, err := io.WriteString(t.w, b.String())
+	return err
+}
+
+func (t *tapWriter) Close() error {
+	if t.tests == 0 {
+		_, err := fmt.Fprintln(t.w, "TAP version 13\n1..0")
+		return err
+	}
+	_, err := fmt.Fprintf(t.w, "1..%d\n", t.tests)
+	return err
+}
+
+// escapeTAP escapes the characters that have a meaning in a TAP test line
+func escapeTAP(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "#", `\#`)
+}
+
+// writeYAMLField writes an indented YAML field, using a literal block for multi-line values
+func writeYAMLField(b *strings.Builder, key, value string) {
+	if !strings.Contains(value, "\n") {
+		fmt.Fprintf(b, "  %s: %q\n", key, value)
+		return
+	}
+
+	// The indentation indicator allows lines starting with spaces,
+	// and the chomping indicator keeps the trailing newline only if there is one
+	chomping := "-"
+	if strings.HasSuffix(value, "\n") {
+		chomping = "+"
+		value = strings.TrimSuffix(value, "\n")
+	}
+	fmt.Fprintf(b, "  %s: |2%s\n", key, chomping)
+	for _, line := range strings.Split(value, "\n") {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(b, "    %s\n", line)
+	}
+}
+
+// markdownWriter writes a row per day with the answer of each part, once all the entries of the day are written
+type markdownWriter struct {
+	w       io.Writer
+	header  bool
+	current []Entry
+}
+
+func (m *markdownWriter) Write(e Entry) error {
+	if len(m.current) > 0 && m.current[0].Day.Number != e.Day.Number {
+		if err := m.flush(); err != nil {
+			return err
+		}
+	}
+	m.current = append(m.current, e)
+	return nil
+}
+
+func (m *markdownWriter) Close() error {
+	return m.flush()
+}
+
+func (m *markdownWriter) flush() error {
+	if !m.header {
+		m.header = true
+		if _, err := fmt.Fprint(m.w, "| Day | Title | Part One | Part Two | Time |\n| ---: | --- | --- | --- | ---: |\n"); err != nil {
+			return err
+		}
+	}
+	if len(m.current) == 0 {
+		return nil
+	}
+
+	day := m.current[0].Day
+	cells := [2]string{"", ""}
+	var total time.Duration
+	for _, e := range m.current {
+		switch {
+		case e.Skipped != "":
+			cells = [2]string{"_skipped: " + escapeMarkdown(e.Skipped) + "_", ""}
+		case e.Part == 0:
+			cells = [2]string{"**error:** " + escapeMarkdown(e.Err.Error()), ""}
+		case e.Part == 1 || e.Part == 2:
+			cells[e.Part-1] = markdownCell(e)
+			total += e.Duration
+		}
+	}
+
+	duration := ""
+	if total > 0 {
+		duration = total.Round(time.Millisecond).String()
+	}
+	m.current = nil
+
+	_, err := fmt.Fprintf(m.w, "| %d | %s | %s | %s | %s |\n", day.Number, escapeMarkdown(day.Title), cells[0], cells[1], duration)
+	return err
+}
+
+func markdownCell(e Entry) string {
+	if e.Err != nil {
+		return "**error:** " + escapeMarkdown(e.Err.Error())
+	}
+
+	cell := markdownCode(e.Answer)
+	if e.Verdict == "FAIL" {
+		cell += " ❌ expected " + markdownCode(e.Expected)
+	}
+	return cell
+}
+
+func markdownCode(answer string) string {
+	if !strings.Contains(answer, "\n") {
+		return "`" + escapeMarkdown(answer) + "`"
+	}
+
+	// Table cells can't span several lines, so multi-line answers are rendered as HTML
+	lines := strings.Split(strings.Trim(answer, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = escapeMarkdown(html.EscapeString(line))
+	}
+	return "<pre>" + strings.Join(lines, "<br>") + "</pre>"
+}
+
+// escapeMarkdown escapes the characters that would break a table cell
+func escapeMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
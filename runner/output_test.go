@@ -0,0 +1,189 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+)
+
+var (
+	day1  = registry.Day{Number: 1, Title: "Calorie Counting"}
+	day10 = registry.Day{Number: 10, Title: "Cathode-Ray Tube"}
+	day19 = registry.Day{Number: 19, Title: "Not Enough Minerals"}
+
+	entries = []Entry{
+		{Day: day1, Part: 1, Answer: "24000", Duration: time.Millisecond},
+		{Day: day1, Part: 2, Err: errors.New("boom"), Duration: time.Millisecond},
+		{Day: day10, Part: 2, Answer: "\n##..\n#|.#\n", Duration: 2 * time.Millisecond},
+		{Day: day19, Skipped: "It takes ~5min to run"},
+	}
+)
+
+func write(t *testing.T, format string, entries []Entry) string {
+	var b bytes.Buffer
+	w, err := NewOutputWriter(&b, format)
+	require.NoError(t, err)
+
+	for _, e := range entries {
+		require.NoError(t, w.Write(e))
+	}
+	require.NoError(t, w.Close())
+
+	return b.String()
+}
+
+func TestEntriesOfShould(t *testing.T) {
+	t.Run("return an entry per part", func(t *testing.T) {
+		result := Result{
+			Day: day1,
+			Parts: []PartResult{
+				{Part: 1, Answer: "1", Solve: Measurement{Duration: time.Second}},
+				{Part: 2, Err: errors.New("boom")},
+			},
+		}
+
+		expected := []Entry{
+			{Day: day1, Part: 1, Answer: "1", Duration: time.Second},
+			{Day: day1, Part: 2, Err: errors.New("boom")},
+		}
+		assert.Equal(t, expected, EntriesOf(result))
+	})
+
+	t.Run("return an entry for the day if its input can't be read", func(t *testing.T) {
+		result := Result{Day: day1, Err: errors.New("no input")}
+
+		assert.Equal(t, []Entry{{Day: day1, Err: errors.New("no input")}}, EntriesOf(result))
+	})
+}
+
+func TestOutputWriterShould(t *testing.T) {
+	t.Run("write text", func(t *testing.T) {
+		expected := `
+Running day 1: Calorie Counting
+Part One: 24000
+[ERROR] Part Two: boom
+
+Running day 10: Cathode-Ray Tube
+Part Two: ` + `
+##..
+#|.#
+
+
+Running day 19: Not Enough Minerals
+[DISABLED] It takes ~5min to run
+`
+		assert.Equal(t, expected, write(t, "text", entries))
+	})
+
+	t.Run("write text with verdicts", func(t *testing.T) {
+		expected := `
+Running day 1: Calorie Counting
+[FAIL] Part One: 24000
+Expected: 24001
+`
+		assert.Equal(t, expected, write(t, "text", []Entry{{Day: day1, Part: 1, Answer: "24000", Verdict: "FAIL", Expected: "24001"}}))
+	})
+
+	t.Run("write text with explanations", func(t *testing.T) {
+		expected := `
+Running day 1: Calorie Counting
+Part One: 24000
+The fourth elf carries the most
+`
+		assert.Equal(t, expected, write(t, "text", []Entry{{Day: day1, Part: 1, Answer: "24000", Explanation: "The fourth elf carries the most"}}))
+	})
+
+	t.Run("write JSON", func(t *testing.T) {
+		expected := `[
+  {
+    "day": 1,
+    "title": "Calorie Counting",
+    "part": 1,
+    "answer": "24000",
+    "duration_ns": 1000000
+  },
+  {
+    "day": 1,
+    "title": "Calorie Counting",
+    "part": 2,
+    "duration_ns": 1000000,
+    "error": "boom"
+  },
+  {
+    "day": 10,
+    "title": "Cathode-Ray Tube",
+    "part": 2,
+    "answer": "\n##..\n#|.#\n",
+    "duration_ns": 2000000
+  },
+  {
+    "day": 19,
+    "title": "Not Enough Minerals",
+    "skipped": "It takes ~5min to run"
+  }
+]
+`
+		assert.Equal(t, expected, write(t, "json", entries))
+	})
+
+	t.Run("write an empty JSON array", func(t *testing.T) {
+		assert.Equal(t, "[]\n", write(t, "json", nil))
+	})
+
+	t.Run("write TAP", func(t *testing.T) {
+		expected := `TAP version 13
+ok 1 - day 1 part one: 24000
+not ok 2 - day 1 part two
+  ---
+  message: "boom"
+  ...
+ok 3 - day 10 part two
+  ---
+  answer: |2+
+
+    ##..
+    #|.#
+  ...
+ok 4 - day 19 # SKIP It takes ~5min to run
+1..4
+`
+		assert.Equal(t, expected, write(t, "tap", entries))
+	})
+
+	t.Run("write TAP with verdicts", func(t *testing.T) {
+		expected := `TAP version 13
+not ok 1 - day 1 part one: 2\#4
+  ---
+  verdict: "FAIL"
+  expected: "24001"
+  ...
+ok 2 - day 1 part two: 1
+1..2
+`
+		verified := []Entry{
+			{Day: day1, Part: 1, Answer: "2#4", Verdict: "FAIL", Expected: "24001"},
+			{Day: day1, Part: 2, Answer: "1", Verdict: "PASS"},
+		}
+		assert.Equal(t, expected, write(t, "tap", verified))
+	})
+
+	t.Run("write a Markdown table", func(t *testing.T) {
+		expected := "| Day | Title | Part One | Part Two | Time |\n" +
+			"| ---: | --- | --- | --- | ---: |\n" +
+			"| 1 | Calorie Counting | `24000` | **error:** boom | 2ms |\n" +
+			"| 10 | Cathode-Ray Tube |  | <pre>##..<br>#\\|.#</pre> | 2ms |\n" +
+			"| 19 | Not Enough Minerals | _skipped: It takes ~5min to run_ |  |  |\n"
+		assert.Equal(t, expected, write(t, "markdown", entries))
+	})
+
+	t.Run("fail for unknown format", func(t *testing.T) {
+		_, err := NewOutputWriter(&bytes.Buffer{}, "xml")
+		assert.Error(t, err)
+	})
+}
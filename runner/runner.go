@@ -0,0 +1,202 @@
+// Package runner solves days concurrently and collects their answers and errors.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+)
+
+// Options holds the options to run the days
+type Options struct {
+	// Workers is the maximum number of parts solved at the same time, it must be at least 1
+	Workers int
+	// Parts holds the parts to solve for each day
+	Parts []int
+	// ReadInput returns the puzzle input of a day
+	ReadInput func(day registry.Day) (string, error)
+	// Timeout is the maximum time to solve each part, there is no limit if it's 0
+	Timeout time.Duration
+	// MeasureMemory measures the memory used to create each day and solve each part besides the time
+	MeasureMemory bool
+	// Explain asks the days that implement registry.Explainer to explain their answers
+	Explain bool
+}
+
+// Result holds the outcome of running a day
+type Result struct {
+	Day registry.Day
+	// Err is set when the input of the day could not be read, in which case no part is solved
+	Err   error
+	Parts []PartResult
+}
+
+// PartResult holds the outcome of solving one part of a day
+type PartResult struct {
+	Part   int
+	Answer string
+	// Explanation holds how the answer was reached, it's only set when explaining days that implement registry.Explainer
+	Explanation string
+	Err         error
+	// New holds the resources used to create the day
+	New Measurement
+	// Solve holds the resources used to solve the part
+	Solve Measurement
+}
+
+// Run solves the given days and sends their results in the same order as the days.
+// A day that fails or panics doesn't stop the others, its errors are reported in its result.
+// Each part is solved on its own instance of the day, so parts never share state even if they run concurrently.
+// Parts that are still running when the context is done fail with the context error.
+// The returned channel is closed once all the days have been run.
+func Run(ctx context.Context, days []registry.Day, opts Options) <-chan Result {
+	workers := make(chan struct{}, max(opts.Workers, 1))
+
+	pending := make([]chan Result, 0, len(days))
+	for _, day := range days {
+		done := make(chan Result, 1)
+		pending = append(pending, done)
+		go func() {
+			done <- runDay(ctx, day, opts, workers)
+		}()
+	}
+
+	results := make(chan Result)
+	go func() {
+		defer close(results)
+		for _, done := range pending {
+			results <- <-done
+		}
+	}()
+	return results
+}
+
+func runDay(ctx context.Context, day registry.Day, opts Options, workers chan struct{}) Result {
+	result := Result{Day: day}
+
+	var input string
+	err := catch(func() (err error) {
+		input, err = opts.ReadInput(day)
+		return err
+	})
+	if err != nil {
+		result.Err = fmt.Errorf("could not read input: %w", err)
+		return result
+	}
+
+	result.Parts = make([]PartResult, len(opts.Parts))
+	var wg sync.WaitGroup
+	for i, part := range opts.Parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			result.Parts[i] = runPart(ctx, day, input, part, opts)
+		}()
+	}
+	wg.Wait()
+
+	return result
+}
+
+func runPart(ctx context.Context, day registry.Day, input string, part int, opts Options) PartResult {
+	result := PartResult{Part: part}
+	measure := measureTime
+	if opts.MeasureMemory {
+		measure = measureMemory
+	}
+
+	var solver registry.Solver
+	var err error
+	result.New = measure(func() {
+		err = catch(func() (err error) {
+			solver, err = day.New(input)
+			return err
+		})
+	})
+	if err != nil {
+		result.Err = fmt.Errorf("could not create day %d: %w", day.Number, err)
+		return result
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	result.Solve = measure(func() {
+		result.Err = catch(func() (err error) {
+			if opts.Explain {
+				result.Answer, result.Explanation, err = Explain(ctx, solver, part)
+				return err
+			}
+			result.Answer, err = Solve(ctx, solver, part)
+			return err
+		})
+	})
+	if errors.Is(result.Err, context.DeadlineExceeded) {
+		result.Err = fmt.Errorf("timed out after %s: %w", opts.Timeout, result.Err)
+	}
+	if result.Err != nil {
+		result.Err = fmt.Errorf("could not solve part %s for day %d: %w", strings.ToLower(PartName(part)), day.Number, result.Err)
+	}
+	return result
+}
+
+// catch runs f and returns the panic it raises as an error
+func catch(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return f()
+}
+
+// Solve solves the given part of a day, stopping when the context is done
+func Solve(ctx context.Context, solver registry.Solver, part int) (string, error) {
+	contextSolver := registry.WithContext(solver)
+	switch part {
+	case 1:
+		return contextSolver.SolvePartOneContext(ctx)
+	case 2:
+		return contextSolver.SolvePartTwoContext(ctx)
+	}
+	return "", fmt.Errorf("invalid part %d", part)
+}
+
+// Explain solves the given part of a day and explains its answer, stopping when the context is done.
+// The explanation is empty if the day doesn't implement registry.Explainer.
+func Explain(ctx context.Context, solver registry.Solver, part int) (answer, explanation string, err error) {
+	explainer, ok := solver.(registry.Explainer)
+	if !ok {
+		answer, err = Solve(ctx, solver, part)
+		return answer, "", err
+	}
+	switch part {
+	case 1:
+		return explainer.ExplainPartOneContext(ctx)
+	case 2:
+		return explainer.ExplainPartTwoContext(ctx)
+	}
+	return "", "", fmt.Errorf("invalid part %d", part)
+}
+
+// PartName returns the name of a part, such as "One" for part 1
+func PartName(part int) string {
+	switch part {
+	case 1:
+		return "One"
+	case 2:
+		return "Two"
+	}
+	return fmt.Sprintf("%d", part)
+}
@@ -0,0 +1,211 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+)
+
+type fakeSolver struct {
+	input   string
+	delay   time.Duration
+	err     error
+	running *atomic.Int32
+	maxSeen *atomic.Int32
+}
+
+func (f fakeSolver) solve(part string) (string, error) {
+	if f.running != nil {
+		running := f.running.Add(1)
+		defer f.running.Add(-1)
+		for {
+			seen := f.maxSeen.Load()
+			if running <= seen || f.maxSeen.CompareAndSwap(seen, running) {
+				break
+			}
+		}
+	}
+	time.Sleep(f.delay)
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.input + "-" + part, nil
+}
+
+func (f fakeSolver) SolvePartOne() (string, error) {
+	return f.solve("1")
+}
+
+func (f fakeSolver) SolvePartTwo() (string, error) {
+	return f.solve("2")
+}
+
+// fakeExplainer is a fakeSolver that explains its answers
+type fakeExplainer struct {
+	fakeSolver
+}
+
+func (f fakeExplainer) ExplainPartOneContext(context.Context) (string, string, error) {
+	answer, err := f.solve("1")
+	return answer, "because of " + f.input, err
+}
+
+func (f fakeExplainer) ExplainPartTwoContext(context.Context) (string, string, error) {
+	answer, err := f.solve("2")
+	return answer, "because of " + f.input, err
+}
+
+func fakeDay(number int, solver fakeSolver) registry.Day {
+	return registry.Day{
+		Number: number,
+		New: func(input string) (registry.Solver, error) {
+			s := solver
+			s.input = input
+			return s, nil
+		},
+	}
+}
+
+func readFakeInput(day registry.Day) (string, error) {
+	return fmt.Sprintf("day%d", day.Number), nil
+}
+
+func collect(results <-chan Result) []Result {
+	var collected []Result
+	for result := range results {
+		collected = append(collected, result)
+	}
+	return collected
+}
+
+func TestRunShould(t *testing.T) {
+	t.Run("return results in the order of the days", func(t *testing.T) {
+		days := []registry.Day{
+			fakeDay(1, fakeSolver{delay: 30 * time.Millisecond}),
+			fakeDay(2, fakeSolver{}),
+			fakeDay(3, fakeSolver{delay: 10 * time.Millisecond}),
+		}
+
+		results := collect(Run(context.Background(), days, Options{Workers: 4, Parts: []int{1, 2}, ReadInput: readFakeInput}))
+
+		require.Len(t, results, 3)
+		for i, result := range results {
+			assert.Equal(t, i+1, result.Day.Number)
+			assert.NoError(t, result.Err)
+			require.Len(t, result.Parts, 2)
+			for j, part := range result.Parts {
+				assert.Equal(t, j+1, part.Part)
+				assert.Equal(t, fmt.Sprintf("day%d-%d", i+1, j+1), part.Answer)
+				assert.NoError(t, part.Err)
+			}
+		}
+	})
+
+	t.Run("not solve more parts than workers at the same time", func(t *testing.T) {
+		running, maxSeen := &atomic.Int32{}, &atomic.Int32{}
+		solver := fakeSolver{delay: 10 * time.Millisecond, running: running, maxSeen: maxSeen}
+		days := []registry.Day{fakeDay(1, solver), fakeDay(2, solver), fakeDay(3, solver), fakeDay(4, solver)}
+
+		results := collect(Run(context.Background(), days, Options{Workers: 3, Parts: []int{1, 2}, ReadInput: readFakeInput}))
+
+		assert.Len(t, results, 4)
+		assert.LessOrEqual(t, maxSeen.Load(), int32(3))
+		assert.Greater(t, maxSeen.Load(), int32(1))
+	})
+
+	t.Run("collect errors of each day", func(t *testing.T) {
+		errSolve := errors.New("solve failed")
+		days := []registry.Day{
+			fakeDay(1, fakeSolver{err: errSolve}),
+			fakeDay(2, fakeSolver{}),
+			{Number: 3, New: func(string) (registry.Solver, error) { return nil, errors.New("invalid input") }},
+		}
+
+		results := collect(Run(context.Background(), days, Options{Workers: 2, Parts: []int{2}, ReadInput: readFakeInput}))
+
+		require.Len(t, results, 3)
+		assert.ErrorIs(t, results[0].Parts[0].Err, errSolve)
+		assert.EqualError(t, results[0].Parts[0].Err, "could not solve part two for day 1: solve failed")
+		assert.Equal(t, "day2-2", results[1].Parts[0].Answer)
+		assert.EqualError(t, results[2].Parts[0].Err, "could not create day 3: invalid input")
+	})
+
+	t.Run("not solve any part if the input can't be read", func(t *testing.T) {
+		days := []registry.Day{fakeDay(1, fakeSolver{})}
+		readInput := func(registry.Day) (string, error) { return "", errors.New("no such file") }
+
+		results := collect(Run(context.Background(), days, Options{Workers: 1, Parts: []int{1, 2}, ReadInput: readInput}))
+
+		require.Len(t, results, 1)
+		assert.EqualError(t, results[0].Err, "could not read input: no such file")
+		assert.Empty(t, results[0].Parts)
+	})
+
+	t.Run("recover from days that panic", func(t *testing.T) {
+		days := []registry.Day{
+			{Number: 1, New: func(string) (registry.Solver, error) { panic("invalid input") }},
+			fakeDay(2, fakeSolver{}),
+		}
+
+		results := collect(Run(context.Background(), days, Options{Workers: 1, Parts: []int{1}, ReadInput: readFakeInput}))
+
+		require.Len(t, results, 2)
+		assert.EqualError(t, results[0].Parts[0].Err, "could not create day 1: panic: invalid input")
+		assert.Equal(t, "day2-1", results[1].Parts[0].Answer)
+	})
+
+	t.Run("only measure memory when asked", func(t *testing.T) {
+		days := []registry.Day{fakeDay(1, fakeSolver{})}
+
+		results := collect(Run(context.Background(), days, Options{Workers: 1, Parts: []int{1}, ReadInput: readFakeInput}))
+		require.Len(t, results, 1)
+		assert.Zero(t, results[0].Parts[0].Solve.PeakHeap)
+
+		results = collect(Run(context.Background(), days, Options{Workers: 1, Parts: []int{1}, ReadInput: readFakeInput, MeasureMemory: true}))
+		require.Len(t, results, 1)
+		assert.NotZero(t, results[0].Parts[0].Solve.PeakHeap)
+	})
+
+	t.Run("only explain the answers when asked", func(t *testing.T) {
+		explained := registry.Day{
+			Number: 1,
+			New: func(input string) (registry.Solver, error) {
+				return fakeExplainer{fakeSolver{input: input}}, nil
+			},
+		}
+		days := []registry.Day{explained, fakeDay(2, fakeSolver{})}
+
+		results := collect(Run(context.Background(), days, Options{Workers: 1, Parts: []int{2}, ReadInput: readFakeInput}))
+		require.Len(t, results, 2)
+		assert.Equal(t, "day1-2", results[0].Parts[0].Answer)
+		assert.Empty(t, results[0].Parts[0].Explanation)
+
+		results = collect(Run(context.Background(), days, Options{Workers: 1, Parts: []int{2}, ReadInput: readFakeInput, Explain: true}))
+		require.Len(t, results, 2)
+		assert.Equal(t, "day1-2", results[0].Parts[0].Answer)
+		assert.Equal(t, "because of day1", results[0].Parts[0].Explanation)
+		assert.Equal(t, "day2-2", results[1].Parts[0].Answer)
+		assert.Empty(t, results[1].Parts[0].Explanation)
+	})
+
+	t.Run("fail parts that exceed the timeout", func(t *testing.T) {
+		days := []registry.Day{fakeDay(1, fakeSolver{delay: time.Second}), fakeDay(2, fakeSolver{})}
+
+		start := time.Now()
+		results := collect(Run(context.Background(), days, Options{Workers: 2, Parts: []int{1}, ReadInput: readFakeInput, Timeout: 10 * time.Millisecond}))
+
+		require.Len(t, results, 2)
+		assert.ErrorIs(t, results[0].Parts[0].Err, context.DeadlineExceeded)
+		assert.EqualError(t, results[0].Parts[0].Err, "could not solve part one for day 1: timed out after 10ms: context deadline exceeded")
+		assert.Equal(t, "day2-1", results[1].Parts[0].Answer)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+}
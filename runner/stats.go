@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Stages of a day that are measured
+const (
+	StageNewDay       = "NewDay"
+	StageSolvePartOne = "SolvePartOne"
+	StageSolvePartTwo = "SolvePartTwo"
+)
+
+// StatsFormats holds the formats supported by WriteStats
+var StatsFormats = []string{"table", "json", "csv"}
+
+// Stat holds the resources used by one stage of a day
+type Stat struct {
+	Day           int    `json:"day"`
+	Stage         string `json:"stage"`
+	DurationNanos int64  `json:"duration_ns"`
+	Allocs        uint64 `json:"allocs"`
+	AllocBytes    uint64 `json:"alloc_bytes"`
+	PeakHeapBytes uint64 `json:"peak_heap_bytes"`
+}
+
+// Stats returns the resources used by each stage of the given results.
+// Since each part creates its own day, the NewDay stage is taken from the first part.
+func Stats(results []Result) []Stat {
+	var stats []Stat
+	for _, result := range results {
+		for i, part := range result.Parts {
+			if i == 0 {
+				stats = append(stats, newStat(result.Day.Number, StageNewDay, part.New))
+			}
+			// The part was not solved if the day could not be created
+			if part.Solve == (Measurement{}) {
+				continue
+			}
+			stats = append(stats, newStat(result.Day.Number, solveStage(part.Part), part.Solve))
+		}
+	}
+	return stats
+}
+
+// solveStage returns the stage that solves the given part
+func solveStage(part int) string {
+	if part == 1 {
+		return StageSolvePartOne
+	}
+	return StageSolvePartTwo
+}
+
+func newStat(day int, stage string, m Measurement) Stat {
+	return Stat{
+		Day:           day,
+		Stage:         stage,
+		DurationNanos: m.Duration.Nanoseconds(),
+		Allocs:        m.Allocs,
+		AllocBytes:    m.AllocBytes,
+		PeakHeapBytes: m.PeakHeap,
+	}
+}
+
+// WriteStats writes the stats in the given format, which must be one of StatsFormats
+func WriteStats(w io.Writer, format string, stats []Stat) error {
+	switch format {
+	case "table":
+		return writeStatsTable(w, stats)
+	case "json":
+		return writeStatsJSON(w, stats)
+	case "csv":
+		return writeStatsCSV(w, stats)
+	}
+	return fmt.Errorf("unknown stats format %q", format)
+}
+
+func writeStatsTable(w io.Writer, stats []Stat) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tStage\tTime\tAllocs\tAllocated\tPeak heap")
+
+	var total time.Duration
+	for _, s := range stats {
+		duration := time.Duration(s.DurationNanos)
+		total += duration
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%s\t%s\n",
+			s.Day, s.Stage, duration.Round(time.Microsecond), s.Allocs, formatBytes(s.AllocBytes), formatBytes(s.PeakHeapBytes))
+	}
+	fmt.Fprintf(tw, "\tTotal\t%s\n", total.Round(time.Microsecond))
+
+	return tw.Flush()
+}
+
+func writeStatsJSON(w io.Writer, stats []Stat) error {
+	if stats == nil {
+		stats = []Stat{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
+
+func writeStatsCSV(w io.Writer, stats []Stat) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{{"day", "stage", "duration_ns", "allocs", "alloc_bytes", "peak_heap_bytes"}}
+	for _, s := range stats {
+		records = append(records, []string{
+			strconv.Itoa(s.Day),
+			s.Stage,
+			strconv.FormatInt(s.DurationNanos, 10),
+			strconv.FormatUint(s.Allocs, 10),
+			strconv.FormatUint(s.AllocBytes, 10),
+			strconv.FormatUint(s.PeakHeapBytes, 10),
+		})
+	}
+	return cw.WriteAll(records)
+}
+
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, exponent := float64(bytes)/unit, 0
+	for value >= unit && exponent < 3 {
+		value /= unit
+		exponent++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exponent])
+}
@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/registry"
+)
+
+var sink []byte
+
+func TestMeasureShould(t *testing.T) {
+	t.Run("measure time and memory", func(t *testing.T) {
+		const size = 8 << 20
+
+		m := measureMemory(func() {
+			sink = make([]byte, size)
+			time.Sleep(5 * time.Millisecond)
+		})
+
+		assert.GreaterOrEqual(t, m.Duration, 5*time.Millisecond)
+		assert.GreaterOrEqual(t, m.Allocs, uint64(1))
+		assert.GreaterOrEqual(t, m.AllocBytes, uint64(size))
+		assert.GreaterOrEqual(t, m.PeakHeap, uint64(size))
+	})
+
+	t.Run("measure only time", func(t *testing.T) {
+		m := measureTime(func() {
+			sink = make([]byte, 1<<20)
+			time.Sleep(5 * time.Millisecond)
+		})
+
+		assert.Equal(t, Measurement{Duration: m.Duration}, m)
+		assert.GreaterOrEqual(t, m.Duration, 5*time.Millisecond)
+	})
+}
+
+func TestStatsShould(t *testing.T) {
+	results := []Result{
+		{
+			Day: registry.Day{Number: 1},
+			Parts: []PartResult{
+				{Part: 1, New: Measurement{Duration: time.Millisecond, Allocs: 1, AllocBytes: 10, PeakHeap: 100}, Solve: Measurement{Duration: 2 * time.Millisecond, Allocs: 2, AllocBytes: 2048, PeakHeap: 3 << 20}},
+				{Part: 2, New: Measurement{Duration: time.Millisecond}, Solve: Measurement{Duration: 3 * time.Millisecond}},
+			},
+		},
+		{
+			Day:   registry.Day{Number: 2},
+			Parts: []PartResult{{Part: 2, New: Measurement{Duration: time.Millisecond}}},
+		},
+	}
+
+	t.Run("return a stat per stage", func(t *testing.T) {
+		expected := []Stat{
+			{Day: 1, Stage: StageNewDay, DurationNanos: 1_000_000, Allocs: 1, AllocBytes: 10, PeakHeapBytes: 100},
+			{Day: 1, Stage: StageSolvePartOne, DurationNanos: 2_000_000, Allocs: 2, AllocBytes: 2048, PeakHeapBytes: 3 << 20},
+			{Day: 1, Stage: StageSolvePartTwo, DurationNanos: 3_000_000},
+			{Day: 2, Stage: StageNewDay, DurationNanos: 1_000_000},
+		}
+
+		assert.Equal(t, expected, Stats(results))
+	})
+
+	t.Run("write stats as a table", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, WriteStats(&b, "table", Stats(results)[:2]))
+
+		expected := `Day  Stage         Time  Allocs  Allocated  Peak heap
+1    NewDay        1ms   1       10 B       100 B
+1    SolvePartOne  2ms   2       2.0 KiB    3.0 MiB
+     Total         3ms
+`
+		assert.Equal(t, expected, b.String())
+	})
+
+	t.Run("write stats as JSON", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, WriteStats(&b, "json", Stats(results)[3:]))
+
+		expected := `[
+  {
+    "day": 2,
+    "stage": "NewDay",
+    "duration_ns": 1000000,
+    "allocs": 0,
+    "alloc_bytes": 0,
+    "peak_heap_bytes": 0
+  }
+]
+`
+		assert.Equal(t, expected, b.String())
+	})
+
+	t.Run("write stats as CSV", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, WriteStats(&b, "csv", Stats(results)[:2]))
+
+		expected := `day,stage,duration_ns,allocs,alloc_bytes,peak_heap_bytes
+1,NewDay,1000000,1,10,100
+1,SolvePartOne,2000000,2,2048,3145728
+`
+		assert.Equal(t, expected, b.String())
+	})
+
+	t.Run("fail for unknown format", func(t *testing.T) {
+		assert.Error(t, WriteStats(&bytes.Buffer{}, "xml", nil))
+	})
+}
@@ -0,0 +1,54 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Summary counts the outcome of the entries of a run
+type Summary struct {
+	Solved  int
+	Failed  int
+	Skipped int
+	// Failures holds the entries that failed, in the order they were added
+	Failures []Entry
+}
+
+// Add counts an entry, which fails if it has an error or its answer is not the known one
+func (s *Summary) Add(e Entry) {
+	switch {
+	case e.Skipped != "":
+		s.Skipped++
+	case e.Err != nil || e.Verdict == "FAIL":
+		s.Failed++
+		s.Failures = append(s.Failures, e)
+	default:
+		s.Solved++
+	}
+}
+
+// OK returns true if no entry failed
+func (s Summary) OK() bool {
+	return s.Failed == 0
+}
+
+// Write writes the counts followed by a line per failure
+func (s Summary) Write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nSummary: %d solved, %d failed, %d skipped\n", s.Solved, s.Failed, s.Skipped)
+	for _, e := range s.Failures {
+		name := fmt.Sprintf("Day %d", e.Day.Number)
+		if e.Part != 0 {
+			name += " part " + strings.ToLower(PartName(e.Part))
+		}
+
+		reason := fmt.Sprintf("expected %q but got %q", e.Expected, e.Answer)
+		if e.Err != nil {
+			reason = e.Err.Error()
+		}
+		fmt.Fprintf(&b, "[FAILED] %s: %s\n", name, reason)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
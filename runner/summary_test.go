@@ -0,0 +1,50 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryShould(t *testing.T) {
+	t.Run("count solved, failed and skipped entries", func(t *testing.T) {
+		var s Summary
+		for _, e := range entries {
+			s.Add(e)
+		}
+
+		assert.Equal(t, 2, s.Solved)
+		assert.Equal(t, 1, s.Failed)
+		assert.Equal(t, 1, s.Skipped)
+		assert.Equal(t, []Entry{entries[1]}, s.Failures)
+		assert.False(t, s.OK())
+	})
+
+	t.Run("be OK without failures", func(t *testing.T) {
+		var s Summary
+		s.Add(Entry{Day: day1, Part: 1, Answer: "1", Verdict: "PASS"})
+		s.Add(Entry{Day: day19, Skipped: "It takes ~5min to run"})
+
+		assert.True(t, s.OK())
+	})
+
+	t.Run("write the failures", func(t *testing.T) {
+		var s Summary
+		s.Add(Entry{Day: day1, Part: 1, Answer: "24000"})
+		s.Add(Entry{Day: day1, Part: 2, Answer: "1", Verdict: "FAIL", Expected: "2"})
+		s.Add(Entry{Day: day10, Err: errors.New("could not read input: no such file")})
+
+		var b bytes.Buffer
+		require.NoError(t, s.Write(&b))
+
+		expected := `
+Summary: 1 solved, 2 failed, 0 skipped
+[FAILED] Day 1 part two: expected "2" but got "1"
+[FAILED] Day 10: could not read input: no such file
+`
+		assert.Equal(t, expected, b.String())
+	})
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// BitSet represents a set of small non-negative integers stored as one bit per integer.
+// It's more compact and faster than Set[int] when the integers are dense, e.g. indexes or ids.
+// The zero value is an empty set ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet returns a new bit set
+func NewBitSet(values ...int) *BitSet {
+	s := &BitSet{}
+	s.Add(values...)
+	return s
+}
+
+// Add adds an integer to the set, it panics if it's negative
+func (s *BitSet) Add(values ...int) {
+	for _, v := range values {
+		word, bit := position(v)
+		if word >= len(s.words) {
+			s.words = append(s.words, make([]uint64, word-len(s.words)+1)...)
+		}
+		s.words[word] |= 1 << bit
+	}
+}
+
+// Remove removes an integer from the set
+func (s *BitSet) Remove(values ...int) {
+	for _, v := range values {
+		if v < 0 {
+			continue
+		}
+		if word, bit := position(v); word < len(s.words) {
+			s.words[word] &^= 1 << bit
+		}
+	}
+}
+
+// Contains returns true if the integer is in the set
+func (s *BitSet) Contains(v int) bool {
+	if v < 0 {
+		return false
+	}
+	word, bit := position(v)
+	return word < len(s.words) && s.words[word]&(1<<bit) != 0
+}
+
+// Len returns the number of members of the set
+func (s *BitSet) Len() int {
+	n := 0
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Members returns all the members of the set in ascending order
+func (s *BitSet) Members() []int {
+	result := make([]int, 0, s.Len())
+	for v := range s.All() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// All returns an iterator over the members of the set in ascending order
+func (s *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range s.words {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*64 + bit) {
+					return
+				}
+				w &^= 1 << bit
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the set
+func (s *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), s.words...)}
+}
+
+// Union returns a new set with the members of both sets
+func (s *BitSet) Union(o *BitSet) *BitSet {
+	return combine(s, o, func(a, b uint64) uint64 { return a | b })
+}
+
+// Intersection returns a new set with the members that are in both sets
+func (s *BitSet) Intersection(o *BitSet) *BitSet {
+	return combine(s, o, func(a, b uint64) uint64 { return a & b })
+}
+
+// Difference returns a new set with the members of this set that are not in the other one
+func (s *BitSet) Difference(o *BitSet) *BitSet {
+	return combine(s, o, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// SymmetricDifference returns a new set with the members that are in only one of both sets
+func (s *BitSet) SymmetricDifference(o *BitSet) *BitSet {
+	return combine(s, o, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// IsSubset returns true if all the members of this set are in the other one
+func (s *BitSet) IsSubset(o *BitSet) bool {
+	return s.Difference(o).Len() == 0
+}
+
+// Equal returns true if both sets have the same members
+func (s *BitSet) Equal(o *BitSet) bool {
+	return s.SymmetricDifference(o).Len() == 0
+}
+
+// combine returns a new set where each word is the result of f on the words of both sets,
+// missing words are considered empty
+func combine(s, o *BitSet, f func(a, b uint64) uint64) *BitSet {
+	result := &BitSet{words: make([]uint64, max(len(s.words), len(o.words)))}
+	for i := range result.words {
+		var a, b uint64
+		if i < len(s.words) {
+			a = s.words[i]
+		}
+		if i < len(o.words) {
+			b = o.words[i]
+		}
+		result.words[i] = f(a, b)
+	}
+	return result
+}
+
+// position returns the word and the bit inside that word that store the given integer
+func position(v int) (int, uint) {
+	if v < 0 {
+		panic(fmt.Sprintf("bit set can't hold negative integer %d", v))
+	}
+	return v / 64, uint(v % 64)
+}
@@ -0,0 +1,87 @@
+package util
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSetShould(t *testing.T) {
+	t.Run("add, remove and check members", func(t *testing.T) {
+		s := NewBitSet(1, 64, 200)
+		s.Add(5)
+		s.Remove(64, 300, -1)
+
+		assert.True(t, s.Contains(1))
+		assert.True(t, s.Contains(5))
+		assert.True(t, s.Contains(200))
+		assert.False(t, s.Contains(64))
+		assert.False(t, s.Contains(-1))
+		assert.False(t, s.Contains(1000))
+		assert.Equal(t, 3, s.Len())
+	})
+
+	t.Run("work from its zero value", func(t *testing.T) {
+		var s BitSet
+		assert.Equal(t, 0, s.Len())
+
+		s.Add(3)
+		assert.True(t, s.Contains(3))
+	})
+
+	t.Run("iterate over the members in ascending order", func(t *testing.T) {
+		s := NewBitSet(130, 0, 63, 64)
+
+		assert.Equal(t, []int{0, 63, 64, 130}, s.Members())
+		assert.Equal(t, []int{0, 63, 64, 130}, slices.Collect(s.All()))
+	})
+
+	t.Run("clone without sharing members", func(t *testing.T) {
+		s := NewBitSet(1)
+		clone := s.Clone()
+		clone.Add(2)
+
+		assert.Equal(t, []int{1}, s.Members())
+		assert.Equal(t, []int{1, 2}, clone.Members())
+	})
+
+	t.Run("panic with negative integers", func(t *testing.T) {
+		assert.Panics(t, func() { NewBitSet(-1) })
+	})
+}
+
+func TestBitSetAlgebraShould(t *testing.T) {
+	a, b := NewBitSet(1, 2, 100), NewBitSet(2, 3)
+
+	t.Run("return the union", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3, 100}, a.Union(b).Members())
+	})
+
+	t.Run("return the intersection", func(t *testing.T) {
+		assert.Equal(t, []int{2}, a.Intersection(b).Members())
+	})
+
+	t.Run("return the difference", func(t *testing.T) {
+		assert.Equal(t, []int{1, 100}, a.Difference(b).Members())
+		assert.Equal(t, []int{3}, b.Difference(a).Members())
+	})
+
+	t.Run("return the symmetric difference", func(t *testing.T) {
+		assert.Equal(t, []int{1, 3, 100}, a.SymmetricDifference(b).Members())
+	})
+
+	t.Run("check subsets", func(t *testing.T) {
+		assert.True(t, NewBitSet(2).IsSubset(b))
+		assert.False(t, a.IsSubset(b))
+		assert.True(t, NewBitSet().IsSubset(a))
+	})
+
+	t.Run("check equality regardless of the capacity", func(t *testing.T) {
+		s := NewBitSet(2, 500)
+		s.Remove(500)
+
+		assert.True(t, s.Equal(NewBitSet(2)))
+		assert.False(t, s.Equal(b))
+	})
+}
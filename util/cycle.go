@@ -0,0 +1,99 @@
+package util
+
+// Cycle describes a sequence that repeats forever: the steps from Start on repeat every Length steps
+type Cycle struct {
+	Start  int
+	Length int
+}
+
+// Equivalent returns the first step that has the same state as step n
+func (c Cycle) Equivalent(n int) int {
+	if n < c.Start {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+// Extrapolate returns the value at step n of a quantity that grows by the same amount every cycle, such as the height
+// of a tower. value is only called with steps up to Start+Length, the end of the first cycle.
+func (c Cycle) Extrapolate(n int, value func(step int) int) int {
+	if n <= c.Start+c.Length {
+		return value(n)
+	}
+	cycles, rest := (n-c.Start)/c.Length, (n-c.Start)%c.Length
+	return value(c.Start+rest) + cycles*(value(c.Start+c.Length)-value(c.Start))
+}
+
+// Floyd finds the cycle of the sequence x0, f(x0), f(f(x0))... keeping only two states in memory
+func Floyd[S comparable](x0 S, f func(S) S) Cycle {
+	// The hare moves twice as fast as the tortoise, they meet inside the cycle at a multiple of its length
+	tortoise, hare := f(x0), f(f(x0))
+	for tortoise != hare {
+		tortoise, hare = f(tortoise), f(f(hare))
+	}
+
+	// Both are now a multiple of the length apart, so moving them at the same speed they meet at the start
+	start := 0
+	tortoise = x0
+	for tortoise != hare {
+		tortoise, hare = f(tortoise), f(hare)
+		start++
+	}
+
+	length := 1
+	for hare = f(tortoise); tortoise != hare; hare = f(hare) {
+		length++
+	}
+
+	return Cycle{Start: start, Length: length}
+}
+
+// Brent finds the cycle of the sequence x0, f(x0), f(f(x0))... keeping only two states in memory,
+// it usually calls f fewer times than Floyd
+func Brent[S comparable](x0 S, f func(S) S) Cycle {
+	// The tortoise teleports to the hare each power of two steps, until the hare comes back to it
+	power, length := 1, 1
+	tortoise, hare := x0, f(x0)
+	for tortoise != hare {
+		if power == length {
+			tortoise = hare
+			power *= 2
+			length = 0
+		}
+		hare = f(hare)
+		length++
+	}
+
+	// With the hare one length ahead, moving both at the same speed they meet at the start
+	tortoise, hare = x0, x0
+	for range length {
+		hare = f(hare)
+	}
+	start := 0
+	for tortoise != hare {
+		tortoise, hare = f(tortoise), f(hare)
+		start++
+	}
+
+	return Cycle{Start: start, Length: length}
+}
+
+// CycleDetector finds cycles in simulations whose state is too large to compare, by remembering the step at which each
+// key was seen. The key must capture everything that determines the following steps.
+type CycleDetector[K comparable] struct {
+	seen map[K]int
+}
+
+// NewCycleDetector returns a detector that hasn't seen any key yet
+func NewCycleDetector[K comparable]() *CycleDetector[K] {
+	return &CycleDetector[K]{seen: make(map[K]int)}
+}
+
+// Observe records the key of a step and returns the cycle if the same key was already seen at an earlier step
+func (d *CycleDetector[K]) Observe(step int, key K) (Cycle, bool) {
+	if previous, ok := d.seen[key]; ok {
+		return Cycle{Start: previous, Length: step - previous}, true
+	}
+	d.seen[key] = step
+	return Cycle{}, false
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rho is the sequence 0, 1, 2, 3, 4, 5, 6, 3, 4, 5, 6... that has a cycle of length 4 starting at step 3
+func rho(x int) int {
+	if x == 6 {
+		return 3
+	}
+	return x + 1
+}
+
+func TestCycleShould(t *testing.T) {
+	c := Cycle{Start: 3, Length: 4}
+
+	t.Run("return the equivalent step", func(t *testing.T) {
+		assert.Equal(t, 2, c.Equivalent(2))
+		assert.Equal(t, 3, c.Equivalent(7))
+		assert.Equal(t, 5, c.Equivalent(1_000_001))
+	})
+
+	t.Run("extrapolate values that grow every cycle", func(t *testing.T) {
+		// The value grows by 10 every step before the cycle, then by 1, 2, 3 and 4 in each cycle
+		values := []int{0, 10, 20, 30, 31, 33, 36, 40}
+		value := func(step int) int { return values[step] }
+
+		assert.Equal(t, 33, c.Extrapolate(5, value))
+		assert.Equal(t, 40, c.Extrapolate(7, value))
+		assert.Equal(t, 41, c.Extrapolate(8, value))
+		assert.Equal(t, 30+10*1_000, c.Extrapolate(3+4*1_000, value))
+		assert.Equal(t, 30+10*1_000+3, c.Extrapolate(3+4*1_000+2, value))
+	})
+}
+
+func TestFloydShould(t *testing.T) {
+	t.Run("find the start and length of the cycle", func(t *testing.T) {
+		assert.Equal(t, Cycle{Start: 3, Length: 4}, Floyd(0, rho))
+	})
+
+	t.Run("find cycles that start at the first step", func(t *testing.T) {
+		assert.Equal(t, Cycle{Start: 0, Length: 5}, Floyd(0, func(x int) int { return (x + 1) % 5 }))
+	})
+
+	t.Run("find fixed points", func(t *testing.T) {
+		assert.Equal(t, Cycle{Start: 1, Length: 1}, Floyd(5, func(x int) int { return x / 10 }))
+	})
+}
+
+func TestBrentShould(t *testing.T) {
+	t.Run("find the same cycles as Floyd", func(t *testing.T) {
+		sequences := map[string]func(int) int{
+			"rho":         rho,
+			"pure cycle":  func(x int) int { return (x + 1) % 5 },
+			"fixed point": func(x int) int { return x / 10 },
+			"lcg":         func(x int) int { return (x*x + 1) % 255 },
+		}
+
+		for name, f := range sequences {
+			assert.Equal(t, Floyd(5, f), Brent(5, f), name)
+		}
+	})
+}
+
+func TestCycleDetectorShould(t *testing.T) {
+	t.Run("detect the first repeated key", func(t *testing.T) {
+		d := NewCycleDetector[int]()
+		x := 0
+		for step := 0; ; step++ {
+			if cycle, ok := d.Observe(step, x); ok {
+				assert.Equal(t, Cycle{Start: 3, Length: 4}, cycle)
+				assert.Equal(t, 7, step)
+				return
+			}
+			x = rho(x)
+		}
+	})
+}
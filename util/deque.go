@@ -0,0 +1,116 @@
+package util
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Deque is a double-ended queue backed by a ring buffer that grows as needed, its size is always a power of two.
+// Unlike re-slicing a slice used as a queue, popped values don't keep the backing array growing.
+// The zero value is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	len  int
+}
+
+// NewDeque returns a deque with the given values, from front to back
+func NewDeque[T any](values ...T) *Deque[T] {
+	d := &Deque[T]{}
+	for _, v := range values {
+		d.PushBack(v)
+	}
+	return d
+}
+
+// Len returns the number of values in the deque
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+// PushBack adds a value at the back
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.physical(d.len)] = v
+	d.len++
+}
+
+// PushFront adds a value at the front
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1) & (len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PopFront removes and returns the value at the front, it panics if the deque is empty
+func (d *Deque[T]) PopFront() T {
+	if d.len == 0 {
+		panic("pop from empty deque")
+	}
+	var zero T
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) & (len(d.buf) - 1)
+	d.len--
+	return v
+}
+
+// PopBack removes and returns the value at the back, it panics if the deque is empty
+func (d *Deque[T]) PopBack() T {
+	if d.len == 0 {
+		panic("pop from empty deque")
+	}
+	var zero T
+	i := d.physical(d.len - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.len--
+	return v
+}
+
+// Front returns the value at the front, it panics if the deque is empty
+func (d *Deque[T]) Front() T {
+	return d.At(0)
+}
+
+// Back returns the value at the back, it panics if the deque is empty
+func (d *Deque[T]) Back() T {
+	return d.At(d.len - 1)
+}
+
+// At returns the i-th value from the front, it panics if i is out of range
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.len {
+		panic(fmt.Sprintf("index %d out of range of deque of length %d", i, d.len))
+	}
+	return d.buf[d.physical(i)]
+}
+
+// All returns an iterator over the values from front to back
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.len {
+			if !yield(d.buf[d.physical(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// physical returns the index in the buffer of the i-th value from the front
+func (d *Deque[T]) physical(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// grow doubles the buffer when it's full, moving the values to its start
+func (d *Deque[T]) grow() {
+	if d.len < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(d.buf), 8))
+	for i := range d.len {
+		buf[i] = d.buf[d.physical(i)]
+	}
+	d.buf, d.head = buf, 0
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDequeShould(t *testing.T) {
+	t.Run("work as a queue", func(t *testing.T) {
+		d := NewDeque(1, 2)
+		d.PushBack(3)
+
+		assert.Equal(t, 1, d.PopFront())
+		assert.Equal(t, 2, d.PopFront())
+		assert.Equal(t, 3, d.PopFront())
+		assert.Equal(t, 0, d.Len())
+	})
+
+	t.Run("work as a stack", func(t *testing.T) {
+		var d Deque[int]
+		d.PushBack(1)
+		d.PushBack(2)
+
+		assert.Equal(t, 2, d.PopBack())
+		assert.Equal(t, 1, d.PopBack())
+	})
+
+	t.Run("push to the front", func(t *testing.T) {
+		d := NewDeque(2, 3)
+		d.PushFront(1)
+
+		assert.Equal(t, 1, d.Front())
+		assert.Equal(t, 3, d.Back())
+		assert.Equal(t, []int{1, 2, 3}, slices.Collect(d.All()))
+	})
+
+	t.Run("keep the order when growing after wrapping around", func(t *testing.T) {
+		var d Deque[int]
+		for i := range 6 {
+			d.PushBack(i)
+		}
+		for range 4 {
+			d.PopFront()
+		}
+		for i := 6; i < 20; i++ {
+			d.PushBack(i)
+		}
+		d.PushFront(3)
+
+		expected := make([]int, 0, 17)
+		for i := 3; i < 20; i++ {
+			expected = append(expected, i)
+		}
+		assert.Equal(t, expected, slices.Collect(d.All()))
+		assert.Equal(t, 5, d.At(2))
+	})
+
+	t.Run("panic when empty", func(t *testing.T) {
+		var d Deque[int]
+
+		assert.Panics(t, func() { d.PopFront() })
+		assert.Panics(t, func() { d.PopBack() })
+		assert.Panics(t, func() { d.Front() })
+	})
+}
+
+func BenchmarkDeque(b *testing.B) {
+	// Keeps a window of values queued while many go through the queue, like a BFS frontier
+	const window, total = 1_000, 100_000
+
+	b.Run("Deque", func(b *testing.B) {
+		for range b.N {
+			var d Deque[int]
+			for i := range total {
+				d.PushBack(i)
+				if d.Len() > window {
+					d.PopFront()
+				}
+			}
+		}
+	})
+
+	b.Run("slice", func(b *testing.B) {
+		for range b.N {
+			var queue []int
+			for i := range total {
+				queue = append(queue, i)
+				if len(queue) > window {
+					queue = queue[1:]
+				}
+			}
+		}
+	})
+}
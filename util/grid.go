@@ -0,0 +1,198 @@
+package util
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Grid represents a rectangular grid of cells stored in row-major order, where the point of a cell holds its column in X
+// and its row in Y.
+// Like slices, copies of a grid share their cells, use Clone to get an independent grid.
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+// NewGrid returns a grid with the given size where all the cells hold the zero value
+func NewGrid[T any](rows, cols int) Grid[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("invalid grid size %dx%d", rows, cols))
+	}
+	return Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// GridFromRows returns a grid with a copy of the given rows, which must have the same length
+func GridFromRows[T any](rows [][]T) (Grid[T], error) {
+	if len(rows) == 0 {
+		return Grid[T]{}, nil
+	}
+
+	g := NewGrid[T](len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != g.cols {
+			return Grid[T]{}, fmt.Errorf("row %d has %d cells but row 0 has %d", i, len(row), g.cols)
+		}
+		copy(g.cells[i*g.cols:], row)
+	}
+	return g, nil
+}
+
+// Rows returns the number of rows of the grid
+func (g Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns of the grid
+func (g Grid[T]) Cols() int {
+	return g.cols
+}
+
+// InBounds returns true if the position is inside the grid
+func (g Grid[T]) InBounds(p Point2) bool {
+	return p.Y >= 0 && p.Y < g.rows && p.X >= 0 && p.X < g.cols
+}
+
+// Get returns the cell at the given position and whether the position is inside the grid
+func (g Grid[T]) Get(p Point2) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Y*g.cols+p.X], true
+}
+
+// At returns the cell at the given position, it panics if the position is outside the grid
+func (g Grid[T]) At(p Point2) T {
+	return g.cells[g.index(p)]
+}
+
+// Set sets the cell at the given position, it panics if the position is outside the grid
+func (g Grid[T]) Set(p Point2, v T) {
+	g.cells[g.index(p)] = v
+}
+
+func (g Grid[T]) index(p Point2) int {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("position %+v is out of the %dx%d grid", p, g.rows, g.cols))
+	}
+	return p.Y*g.cols + p.X
+}
+
+// All returns an iterator over all the cells in row-major order
+func (g Grid[T]) All() iter.Seq2[Point2, T] {
+	return func(yield func(Point2, T) bool) {
+		for i, cell := range g.cells {
+			if !yield(Point2{X: i % g.cols, Y: i / g.cols}, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Row returns an iterator over the cells of a row, from left to right
+func (g Grid[T]) Row(row int) iter.Seq2[Point2, T] {
+	return g.line(Point2{X: 0, Y: row}, East)
+}
+
+// Col returns an iterator over the cells of a column, from top to bottom
+func (g Grid[T]) Col(col int) iter.Seq2[Point2, T] {
+	return g.line(Point2{X: col, Y: 0}, South)
+}
+
+// Walk returns an iterator over the cells found moving from the given position in a direction,
+// excluding the starting position and stopping at the border of the grid
+func (g Grid[T]) Walk(from, direction Point2) iter.Seq2[Point2, T] {
+	return g.line(from.Add(direction), direction)
+}
+
+func (g Grid[T]) line(from, direction Point2) iter.Seq2[Point2, T] {
+	return func(yield func(Point2, T) bool) {
+		for p := from; g.InBounds(p); p = p.Add(direction) {
+			if !yield(p, g.At(p)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbours4 returns an iterator over the orthogonal neighbours of a position that are inside the grid
+func (g Grid[T]) Neighbours4(p Point2) iter.Seq2[Point2, T] {
+	return g.neighbours(p, Compass4)
+}
+
+// Neighbours8 returns an iterator over the orthogonal and diagonal neighbours of a position that are inside the grid
+func (g Grid[T]) Neighbours8(p Point2) iter.Seq2[Point2, T] {
+	return g.neighbours(p, Compass8)
+}
+
+func (g Grid[T]) neighbours(p Point2, directions []Point2) iter.Seq2[Point2, T] {
+	return func(yield func(Point2, T) bool) {
+		for _, direction := range directions {
+			neighbour := p.Add(direction)
+			if cell, ok := g.Get(neighbour); ok && !yield(neighbour, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the position of the first cell, in row-major order, that satisfies the predicate
+func (g Grid[T]) Find(predicate func(T) bool) (Point2, bool) {
+	for p, cell := range g.All() {
+		if predicate(cell) {
+			return p, true
+		}
+	}
+	return Point2{}, false
+}
+
+// Clone returns a copy of the grid that doesn't share its cells
+func (g Grid[T]) Clone() Grid[T] {
+	clone := NewGrid[T](g.rows, g.cols)
+	copy(clone.cells, g.cells)
+	return clone
+}
+
+// Transpose returns a new grid where the rows are the columns of this one
+func (g Grid[T]) Transpose() Grid[T] {
+	return g.transform(g.cols, g.rows, func(p Point2) Point2 {
+		return Point2{X: p.Y, Y: p.X}
+	})
+}
+
+// RotateClockwise returns a new grid rotated 90 degrees clockwise
+func (g Grid[T]) RotateClockwise() Grid[T] {
+	return g.transform(g.cols, g.rows, func(p Point2) Point2 {
+		return Point2{X: g.rows - 1 - p.Y, Y: p.X}
+	})
+}
+
+// RotateCounterClockwise returns a new grid rotated 90 degrees counterclockwise
+func (g Grid[T]) RotateCounterClockwise() Grid[T] {
+	return g.transform(g.cols, g.rows, func(p Point2) Point2 {
+		return Point2{X: p.Y, Y: g.cols - 1 - p.X}
+	})
+}
+
+// transform returns a new grid with the given size where each cell is moved to the position given by f
+func (g Grid[T]) transform(rows, cols int, f func(Point2) Point2) Grid[T] {
+	transformed := NewGrid[T](rows, cols)
+	for p, cell := range g.All() {
+		transformed.Set(f(p), cell)
+	}
+	return transformed
+}
+
+// Render returns the grid as lines of runes, the inverse of parse.Grid
+func (g Grid[T]) Render(render func(T) rune) string {
+	var b strings.Builder
+	b.Grow(g.rows * (g.cols + 1))
+	for i, cell := range g.cells {
+		if i > 0 && i%g.cols == 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteRune(render(cell))
+	}
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package util
+
+import (
+	"iter"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderRune(r rune) rune {
+	return r
+}
+
+func newTestGrid(t *testing.T, lines ...string) Grid[rune] {
+	rows := make([][]rune, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, []rune(line))
+	}
+	g, err := GridFromRows(rows)
+	require.NoError(t, err)
+	return g
+}
+
+func values[K, V any](seq iter.Seq2[K, V]) []V {
+	var result []V
+	for _, v := range seq {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestGridShould(t *testing.T) {
+	t.Run("build from rows", func(t *testing.T) {
+		g := newTestGrid(t, "abc", "def")
+
+		assert.Equal(t, 2, g.Rows())
+		assert.Equal(t, 3, g.Cols())
+		assert.Equal(t, 'f', g.At(Point2{X: 2, Y: 1}))
+	})
+
+	t.Run("fail to build from rows of different length", func(t *testing.T) {
+		_, err := GridFromRows([][]rune{[]rune("abc"), []rune("de")})
+		assert.EqualError(t, err, "row 1 has 2 cells but row 0 has 3")
+	})
+
+	t.Run("check bounds", func(t *testing.T) {
+		g := newTestGrid(t, "abc", "def")
+
+		cell, ok := g.Get(Point2{X: 1, Y: 0})
+		assert.True(t, ok)
+		assert.Equal(t, 'b', cell)
+
+		_, ok = g.Get(Point2{X: 0, Y: 2})
+		assert.False(t, ok)
+		_, ok = g.Get(Point2{X: -1, Y: 0})
+		assert.False(t, ok)
+		assert.Panics(t, func() { g.At(Point2{X: 3, Y: 0}) })
+	})
+
+	t.Run("set cells", func(t *testing.T) {
+		g := NewGrid[rune](2, 2)
+		g.Set(Point2{X: 0, Y: 1}, 'x')
+
+		assert.Equal(t, 'x', g.At(Point2{X: 0, Y: 1}))
+		assert.Equal(t, rune(0), g.At(Point2{X: 0, Y: 0}))
+	})
+
+	t.Run("iterate over all cells in row-major order", func(t *testing.T) {
+		g := newTestGrid(t, "ab", "cd")
+
+		var positions []Point2
+		for p := range g.All() {
+			positions = append(positions, p)
+		}
+
+		assert.Equal(t, []Point2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}, positions)
+		assert.Equal(t, []rune("abcd"), values(g.All()))
+	})
+
+	t.Run("iterate over rows and columns", func(t *testing.T) {
+		g := newTestGrid(t, "abc", "def")
+
+		assert.Equal(t, []rune("def"), values(g.Row(1)))
+		assert.Equal(t, []rune("cf"), values(g.Col(2)))
+	})
+
+	t.Run("walk in a direction until the border", func(t *testing.T) {
+		g := newTestGrid(t, "abc", "def", "ghi")
+
+		assert.Equal(t, []rune("ed"), values(g.Walk(Point2{X: 2, Y: 1}, West)))
+		assert.Equal(t, []rune("ei"), values(g.Walk(Point2{X: 0, Y: 0}, SouthEast)))
+		assert.Empty(t, values(g.Walk(Point2{X: 0, Y: 0}, North)))
+	})
+
+	t.Run("iterate over neighbours inside the grid", func(t *testing.T) {
+		g := newTestGrid(t, "abc", "def", "ghi")
+
+		assert.Equal(t, []rune("bfhd"), values(g.Neighbours4(Point2{X: 1, Y: 1})))
+		assert.Equal(t, []rune("bd"), values(g.Neighbours4(Point2{X: 0, Y: 0})))
+		assert.Equal(t, []rune("bcfihgda"), values(g.Neighbours8(Point2{X: 1, Y: 1})))
+		assert.Equal(t, []rune("feb"), values(g.Neighbours8(Point2{X: 2, Y: 0})))
+	})
+
+	t.Run("find a cell", func(t *testing.T) {
+		g := newTestGrid(t, "abc", "dEf")
+
+		p, ok := g.Find(func(r rune) bool { return r == 'E' })
+		assert.True(t, ok)
+		assert.Equal(t, Point2{X: 1, Y: 1}, p)
+
+		_, ok = g.Find(func(r rune) bool { return r == 'S' })
+		assert.False(t, ok)
+	})
+
+	t.Run("transpose and rotate", func(t *testing.T) {
+		g := newTestGrid(t, "abc", "def")
+
+		assert.Equal(t, "ad\nbe\ncf", g.Transpose().Render(renderRune))
+		assert.Equal(t, "da\neb\nfc", g.RotateClockwise().Render(renderRune))
+		assert.Equal(t, "cf\nbe\nad", g.RotateCounterClockwise().Render(renderRune))
+		assert.Equal(t, g, g.RotateClockwise().RotateCounterClockwise())
+	})
+
+	t.Run("clone cells", func(t *testing.T) {
+		g := newTestGrid(t, "ab")
+		clone := g.Clone()
+		clone.Set(Point2{X: 0, Y: 0}, 'x')
+
+		assert.Equal(t, "ab", g.Render(renderRune))
+		assert.Equal(t, "xb", clone.Render(renderRune))
+	})
+
+	t.Run("render the parsed lines", func(t *testing.T) {
+		lines := []string{"#..", ".#.", "..#"}
+		g := newTestGrid(t, lines...)
+
+		assert.Equal(t, "#..\n.#.\n..#", g.Render(renderRune))
+	})
+
+	t.Run("work when empty", func(t *testing.T) {
+		g := newTestGrid(t)
+
+		assert.Empty(t, maps.Collect(g.All()))
+		assert.Equal(t, "", g.Render(renderRune))
+	})
+}
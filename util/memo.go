@@ -0,0 +1,32 @@
+package util
+
+// Memo caches the results of a function of a comparable state, so identical subproblems are computed once
+type Memo[K comparable, V any] struct {
+	f     func(recurse func(K) V, key K) V
+	cache map[K]V
+}
+
+// NewMemo returns a cache for f. Recursive functions must call recurse instead of themselves to reuse the cache.
+func NewMemo[K comparable, V any](f func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return &Memo[K, V]{f: f, cache: make(map[K]V)}
+}
+
+// Get returns the result for a key, computing it only the first time
+func (m *Memo[K, V]) Get(key K) V {
+	if v, ok := m.cache[key]; ok {
+		return v
+	}
+	v := m.f(m.Get, key)
+	m.cache[key] = v
+	return v
+}
+
+// Len returns the number of cached results
+func (m *Memo[K, V]) Len() int {
+	return len(m.cache)
+}
+
+// Reset forgets all the cached results
+func (m *Memo[K, V]) Reset() {
+	clear(m.cache)
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoShould(t *testing.T) {
+	t.Run("compute each key once", func(t *testing.T) {
+		calls := 0
+		fibonacci := NewMemo(func(recurse func(int) int, n int) int {
+			calls++
+			if n < 2 {
+				return n
+			}
+			return recurse(n-1) + recurse(n-2)
+		})
+
+		assert.Equal(t, 12586269025, fibonacci.Get(50))
+		assert.Equal(t, 51, calls)
+		assert.Equal(t, 51, fibonacci.Len())
+
+		fibonacci.Get(50)
+		assert.Equal(t, 51, calls)
+	})
+
+	t.Run("work with struct keys", func(t *testing.T) {
+		type state struct{ row, col int }
+		paths := NewMemo(func(recurse func(state) int, s state) int {
+			if s.row == 0 || s.col == 0 {
+				return 1
+			}
+			return recurse(state{s.row - 1, s.col}) + recurse(state{s.row, s.col - 1})
+		})
+
+		assert.Equal(t, 184756, paths.Get(state{10, 10}))
+	})
+
+	t.Run("forget the results when reset", func(t *testing.T) {
+		calls := 0
+		square := NewMemo(func(_ func(int) int, n int) int {
+			calls++
+			return n * n
+		})
+		square.Get(3)
+		square.Reset()
+		square.Get(3)
+
+		assert.Equal(t, 2, calls)
+	})
+}
@@ -0,0 +1,209 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrOverflow is returned when the result of an operation doesn't fit in an int
+var ErrOverflow = errors.New("integer overflow")
+
+// CheckedAdd returns a+b, or ErrOverflow if it doesn't fit in an int
+func CheckedAdd(a, b int) (int, error) {
+	sum := a + b
+	// The sum overflows when both operands have the same sign and the result has the other one
+	if (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrOverflow, a, b)
+	}
+	return sum, nil
+}
+
+// CheckedSub returns a-b, or ErrOverflow if it doesn't fit in an int
+func CheckedSub(a, b int) (int, error) {
+	diff := a - b
+	// The difference overflows when the operands have different signs and the result has the sign of b
+	if (a >= 0) != (b >= 0) && (diff >= 0) != (a >= 0) {
+		return 0, fmt.Errorf("%w: %d - %d", ErrOverflow, a, b)
+	}
+	return diff, nil
+}
+
+// CheckedMul returns a*b, or ErrOverflow if it doesn't fit in an int
+func CheckedMul(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, fmt.Errorf("%w: %d * %d", ErrOverflow, a, b)
+	}
+	return product, nil
+}
+
+// CheckedDiv returns a/b, or an error if b is zero or the result doesn't fit in an int
+func CheckedDiv(a, b int) (int, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("division by zero: %d / %d", a, b)
+	}
+	if a == math.MinInt && b == -1 {
+		return 0, fmt.Errorf("%w: %d / %d", ErrOverflow, a, b)
+	}
+	return a / b, nil
+}
+
+// Arithmetic adds, subtracts, multiplies and divides ints. By default it wraps around on overflow like the built-in
+// operators, and when Checked it fails with ErrOverflow instead.
+type Arithmetic struct {
+	Checked bool
+}
+
+// DefaultArithmetic is the arithmetic used by the days that can check overflows, the --checked flag enables it
+var DefaultArithmetic Arithmetic
+
+// Add returns x+y
+func (a Arithmetic) Add(x, y int) (int, error) {
+	if a.Checked {
+		return CheckedAdd(x, y)
+	}
+	return x + y, nil
+}
+
+// Sub returns x-y
+func (a Arithmetic) Sub(x, y int) (int, error) {
+	if a.Checked {
+		return CheckedSub(x, y)
+	}
+	return x - y, nil
+}
+
+// Mul returns x*y
+func (a Arithmetic) Mul(x, y int) (int, error) {
+	if a.Checked {
+		return CheckedMul(x, y)
+	}
+	return x * y, nil
+}
+
+// Div returns x/y, it always fails if y is zero
+func (a Arithmetic) Div(x, y int) (int, error) {
+	if a.Checked || y == 0 {
+		return CheckedDiv(x, y)
+	}
+	return x / y, nil
+}
+
+// Mod returns a modulo m in the range [0, m), unlike % that keeps the sign of a
+func Mod[T constraints.Integer](a, m T) T {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// GCD returns the greatest common divisor of a and b, which is never negative
+func GCD[T constraints.Integer](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return Abs(a)
+}
+
+// LCM returns the least common multiple of the values, 1 if there are none, or ErrOverflow if it doesn't fit in an int
+func LCM(values ...int) (int, error) {
+	lcm := 1
+	for _, v := range values {
+		if v == 0 {
+			return 0, nil
+		}
+		var err error
+		lcm, err = CheckedMul(lcm/GCD(lcm, v), Abs(v))
+		if err != nil {
+			return 0, fmt.Errorf("could not compute least common multiple of %v: %w", values, err)
+		}
+	}
+	return lcm, nil
+}
+
+// ExtendedGCD returns the greatest common divisor of a and b, and the coefficients x and y such that a*x + b*y = gcd
+func ExtendedGCD(a, b int) (gcd, x, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// ModInverse returns x such that a*x is 1 modulo m, which only exists if a and m are coprime
+func ModInverse(a, m int) (int, error) {
+	gcd, x, _ := ExtendedGCD(Mod(a, m), m)
+	if gcd != 1 {
+		return 0, fmt.Errorf("%d has no inverse modulo %d", a, m)
+	}
+	return Mod(x, m), nil
+}
+
+// MulMod returns a*b modulo m without overflowing, m must be positive
+func MulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m)))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// ModPow returns base to the power of exp modulo m without overflowing, exp must not be negative and m must be positive
+func ModPow(base, exp, m int) int {
+	result := 1 % m
+	base = Mod(base, m)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = MulMod(result, base, m)
+		}
+		base = MulMod(base, base, m)
+	}
+	return result
+}
+
+// CRT solves the system of congruences x = remainders[i] modulo moduli[i] with the Chinese Remainder Theorem.
+// It returns the smallest non-negative solution and the modulo of all the solutions, which is the LCM of the moduli.
+// Moduli don't need to be coprime, but then the system may have no solution.
+func CRT(remainders, moduli []int) (x, m int, err error) {
+	if len(remainders) != len(moduli) {
+		return 0, 0, fmt.Errorf("got %d remainders but %d moduli", len(remainders), len(moduli))
+	}
+
+	x, m = 0, 1
+	for i, n := range moduli {
+		if n <= 0 {
+			return 0, 0, fmt.Errorf("modulo %d is not positive", n)
+		}
+		a := Mod(remainders[i], n)
+
+		// Merge x modulo m with a modulo n: x + m*k = a modulo n, so m*k = a-x modulo n
+		gcd, p, _ := ExtendedGCD(m, n)
+		diff := a - Mod(x, n)
+		if diff%gcd != 0 {
+			return 0, 0, fmt.Errorf("no solution for x = %d modulo %d with x = %d modulo %d", x, m, a, n)
+		}
+
+		lcm, err := CheckedMul(m/gcd, n)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not merge modulo %d: %w", n, err)
+		}
+		reduced := n / gcd
+		k := MulMod(diff/gcd, p, reduced)
+		// x < m and k < n/gcd, so x + m*k < lcm fits in an int
+		x, m = x+m*k, lcm
+	}
+	return x, m, nil
+}
@@ -0,0 +1,222 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedArithmeticShould(t *testing.T) {
+	t.Run("add without overflow", func(t *testing.T) {
+		sum, err := CheckedAdd(math.MaxInt-1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, math.MaxInt, sum)
+
+		sum, err = CheckedAdd(math.MinInt, math.MaxInt)
+		require.NoError(t, err)
+		assert.Equal(t, -1, sum)
+	})
+
+	t.Run("detect additions that overflow", func(t *testing.T) {
+		_, err := CheckedAdd(math.MaxInt, 1)
+		assert.ErrorIs(t, err, ErrOverflow)
+
+		_, err = CheckedAdd(math.MinInt, -1)
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+
+	t.Run("subtract without overflow", func(t *testing.T) {
+		diff, err := CheckedSub(math.MinInt+1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, math.MinInt, diff)
+
+		diff, err = CheckedSub(-1, math.MinInt)
+		require.NoError(t, err)
+		assert.Equal(t, math.MaxInt, diff)
+	})
+
+	t.Run("detect subtractions that overflow", func(t *testing.T) {
+		_, err := CheckedSub(0, math.MinInt)
+		assert.ErrorIs(t, err, ErrOverflow)
+
+		_, err = CheckedSub(math.MinInt, 1)
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+
+	t.Run("multiply without overflow", func(t *testing.T) {
+		product, err := CheckedMul(-3_037_000_499, 3_037_000_499)
+		require.NoError(t, err)
+		assert.Equal(t, -9_223_372_030_926_249_001, product)
+	})
+
+	t.Run("detect multiplications that overflow", func(t *testing.T) {
+		_, err := CheckedMul(3_037_000_500, 3_037_000_500)
+		assert.ErrorIs(t, err, ErrOverflow)
+		assert.ErrorContains(t, err, "3037000500 * 3037000500")
+
+		_, err = CheckedMul(-1, math.MinInt)
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+
+	t.Run("divide", func(t *testing.T) {
+		quotient, err := CheckedDiv(-7, 2)
+		require.NoError(t, err)
+		assert.Equal(t, -3, quotient)
+	})
+
+	t.Run("detect divisions by zero and that overflow", func(t *testing.T) {
+		_, err := CheckedDiv(1, 0)
+		assert.ErrorContains(t, err, "division by zero")
+
+		_, err = CheckedDiv(math.MinInt, -1)
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+}
+
+func TestArithmeticShould(t *testing.T) {
+	t.Run("wrap around on overflow by default", func(t *testing.T) {
+		var a Arithmetic
+
+		sum, err := a.Add(math.MaxInt, 1)
+		require.NoError(t, err)
+		assert.Equal(t, math.MinInt, sum)
+
+		product, err := a.Mul(math.MaxInt, 2)
+		require.NoError(t, err)
+		assert.Equal(t, -2, product)
+
+		_, err = a.Div(1, 0)
+		assert.ErrorContains(t, err, "division by zero")
+	})
+
+	t.Run("fail on overflow when checked", func(t *testing.T) {
+		a := Arithmetic{Checked: true}
+
+		_, err := a.Add(math.MaxInt, 1)
+		assert.ErrorIs(t, err, ErrOverflow)
+
+		_, err = a.Sub(math.MinInt, 1)
+		assert.ErrorIs(t, err, ErrOverflow)
+
+		_, err = a.Mul(math.MaxInt, 2)
+		assert.ErrorIs(t, err, ErrOverflow)
+
+		_, err = a.Div(math.MinInt, -1)
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+}
+
+func TestModShould(t *testing.T) {
+	t.Run("return positive remainders", func(t *testing.T) {
+		assert.Equal(t, 2, Mod(7, 5))
+		assert.Equal(t, 3, Mod(-7, 5))
+		assert.Equal(t, 0, Mod(-10, 5))
+	})
+}
+
+func TestGCDShould(t *testing.T) {
+	t.Run("return the greatest common divisor", func(t *testing.T) {
+		assert.Equal(t, 6, GCD(48, 18))
+		assert.Equal(t, 6, GCD(-48, 18))
+		assert.Equal(t, 5, GCD(0, 5))
+	})
+
+	t.Run("return the extended coefficients", func(t *testing.T) {
+		gcd, x, y := ExtendedGCD(240, 46)
+
+		assert.Equal(t, 2, gcd)
+		assert.Equal(t, gcd, 240*x+46*y)
+	})
+}
+
+func TestLCMShould(t *testing.T) {
+	t.Run("return the least common multiple of all the values", func(t *testing.T) {
+		lcm, err := LCM(23, 19, 13, 17)
+		require.NoError(t, err)
+		assert.Equal(t, 96577, lcm)
+
+		lcm, err = LCM(4, 6, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 60, lcm)
+	})
+
+	t.Run("return 1 without values", func(t *testing.T) {
+		lcm, err := LCM()
+		require.NoError(t, err)
+		assert.Equal(t, 1, lcm)
+	})
+
+	t.Run("detect overflows", func(t *testing.T) {
+		_, err := LCM(math.MaxInt, math.MaxInt-1)
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+}
+
+func TestModularArithmeticShould(t *testing.T) {
+	t.Run("multiply without overflowing", func(t *testing.T) {
+		assert.Equal(t, 1, MulMod(math.MaxInt-1, math.MaxInt-1, math.MaxInt))
+		assert.Equal(t, 2, MulMod(-3, 4, 7))
+	})
+
+	t.Run("raise to a power", func(t *testing.T) {
+		assert.Equal(t, 445, ModPow(4, 13, 497))
+		assert.Equal(t, 1, ModPow(2, 0, 7))
+		assert.Equal(t, 0, ModPow(2, 10, 1))
+		assert.Equal(t, 1, ModPow(2, 1_000_000_006, 1_000_000_007))
+	})
+
+	t.Run("return the inverse", func(t *testing.T) {
+		inverse, err := ModInverse(3, 11)
+		require.NoError(t, err)
+		assert.Equal(t, 4, inverse)
+
+		_, err = ModInverse(4, 8)
+		assert.Error(t, err)
+	})
+}
+
+func TestCRTShould(t *testing.T) {
+	t.Run("solve coprime moduli", func(t *testing.T) {
+		x, m, err := CRT([]int{2, 3, 2}, []int{3, 5, 7})
+		require.NoError(t, err)
+
+		assert.Equal(t, 23, x)
+		assert.Equal(t, 105, m)
+	})
+
+	t.Run("solve moduli that are not coprime", func(t *testing.T) {
+		x, m, err := CRT([]int{3, 5}, []int{4, 6})
+		require.NoError(t, err)
+
+		assert.Equal(t, 11, x)
+		assert.Equal(t, 12, m)
+	})
+
+	t.Run("accept negative remainders", func(t *testing.T) {
+		x, _, err := CRT([]int{-1, -1}, []int{3, 5})
+		require.NoError(t, err)
+
+		assert.Equal(t, 14, x)
+	})
+
+	t.Run("solve large moduli without overflowing", func(t *testing.T) {
+		x, m, err := CRT([]int{1, 2}, []int{1_000_000_007, 998_244_353})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1_000_000_007*998_244_353, m)
+		assert.Equal(t, 1, x%1_000_000_007)
+		assert.Equal(t, 2, x%998_244_353)
+	})
+
+	t.Run("fail when there's no solution", func(t *testing.T) {
+		_, _, err := CRT([]int{1, 2}, []int{4, 6})
+		assert.Error(t, err)
+	})
+
+	t.Run("detect overflows", func(t *testing.T) {
+		_, _, err := CRT([]int{0, 0, 0}, []int{1_000_000_007, 998_244_353, 1_000_000_009})
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+}
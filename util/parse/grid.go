@@ -0,0 +1,27 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+)
+
+// Grid returns a grid with a cell per rune of the given lines, which must have the same length
+func Grid[T any](lines []string, parse func(r rune) (T, error)) (util.Grid[T], error) {
+	rows := make([][]T, 0, len(lines))
+	for i, line := range lines {
+		row := make([]T, 0, len(line))
+		for j, r := range []rune(line) {
+			cell, err := parse(r)
+			if err != nil {
+				return util.Grid[T]{}, &Error{Line: i + 1, Column: j + 1, Err: err}
+			}
+			row = append(row, cell)
+		}
+		if i > 0 && len(row) != len(rows[0]) {
+			return util.Grid[T]{}, &Error{Line: i + 1, Err: fmt.Errorf("line has %d cells but line 1 has %d", len(row), len(rows[0]))}
+		}
+		rows = append(rows, row)
+	}
+	return util.GridFromRows(rows)
+}
@@ -0,0 +1,39 @@
+package parse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+)
+
+func parseDigit(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, errors.New("not a digit")
+	}
+	return int(r - '0'), nil
+}
+
+func TestGridShould(t *testing.T) {
+	t.Run("parse lines", func(t *testing.T) {
+		g, err := Grid([]string{"123", "456"}, parseDigit)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, g.Rows())
+		assert.Equal(t, 3, g.Cols())
+		assert.Equal(t, 6, g.At(util.Point2{X: 2, Y: 1}))
+	})
+
+	t.Run("fail to parse lines of different length", func(t *testing.T) {
+		_, err := Grid([]string{"123", "45"}, parseDigit)
+		assert.EqualError(t, err, "line 2: line has 2 cells but line 1 has 3")
+	})
+
+	t.Run("fail to parse invalid cells with their location", func(t *testing.T) {
+		_, err := Grid([]string{"123", "4x6"}, parseDigit)
+		assert.EqualError(t, err, "line 2, column 2: not a digit")
+	})
+}
@@ -0,0 +1,53 @@
+package parse
+
+import (
+	"strconv"
+)
+
+// Ints returns all the integers found in a line, in order. A minus sign right before the digits makes the integer
+// negative unless it follows another digit, so ranges such as "2-4" are read as 2 and 4.
+func Ints(s string) ([]int, error) {
+	var ints []int
+	for i := 0; i < len(s); {
+		if !startsInt(s, i) {
+			i++
+			continue
+		}
+
+		end := scanInt(s, i)
+		n, err := strconv.Atoi(s[i:end])
+		if err != nil {
+			return nil, &Error{Column: i + 1, Err: err}
+		}
+		ints = append(ints, n)
+		i = end
+	}
+	return ints, nil
+}
+
+func startsInt(s string, i int) bool {
+	if isDigit(s[i]) {
+		return true
+	}
+	return s[i] == '-' && i+1 < len(s) && isDigit(s[i+1]) && (i == 0 || !isDigit(s[i-1]))
+}
+
+// scanInt returns the end of the integer that starts at i, which is i if there's none
+func scanInt(s string, i int) int {
+	end := i
+	if end < len(s) && s[end] == '-' {
+		end++
+	}
+	digits := end
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	if end == digits {
+		return i
+	}
+	return end
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
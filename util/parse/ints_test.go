@@ -0,0 +1,37 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntsShould(t *testing.T) {
+	t.Run("find all the integers", func(t *testing.T) {
+		ints, err := Ints("Sensor at x=2, y=-18: closest beacon is at x=-2, y=15")
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{2, -18, -2, 15}, ints)
+	})
+
+	t.Run("read ranges as positive integers", func(t *testing.T) {
+		ints, err := Ints("2-4,6-8")
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{2, 4, 6, 8}, ints)
+	})
+
+	t.Run("ignore lone minus signs", func(t *testing.T) {
+		ints, err := Ints("a - b -")
+		require.NoError(t, err)
+
+		assert.Empty(t, ints)
+	})
+
+	t.Run("fail with integers out of range", func(t *testing.T) {
+		_, err := Ints("1 99999999999999999999")
+
+		assert.ErrorContains(t, err, "column 3:")
+	})
+}
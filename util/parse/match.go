@@ -0,0 +1,153 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Match parses a line that must follow the pattern exactly, storing the values of its verbs in the targets, in order.
+// The pattern supports the following verbs:
+//
+//	%d an integer, optionally negative, stored in an *int
+//	%s a non-empty string up to the next literal of the pattern, or the end of the line, stored in a *string
+//	%c a single rune stored in a *rune
+//	%% a literal percent sign
+//
+// Errors where the line doesn't match the pattern are *Error with the column that failed.
+func Match(s, pattern string, targets ...any) error {
+	i, t := 0, 0
+	for j := 0; j < len(pattern); j++ {
+		if pattern[j] != '%' || (j+1 < len(pattern) && pattern[j+1] == '%') {
+			start := j
+			if pattern[j] == '%' {
+				j++
+			}
+			if i >= len(s) || s[i] != pattern[j] {
+				return mismatch(s, i, strconv.Quote(literal(pattern[start:])))
+			}
+			i++
+			continue
+		}
+
+		j++
+		if j == len(pattern) {
+			return fmt.Errorf("invalid pattern %q: missing verb after %%", pattern)
+		}
+		if t == len(targets) {
+			return fmt.Errorf("missing target for verb %d of pattern %q", t+1, pattern)
+		}
+
+		end, err := scan(s, i, pattern[j], pattern[j+1:])
+		if err != nil {
+			return err
+		}
+		if err := store(targets[t], pattern[j], s[i:end]); err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return &Error{Column: i + 1, Err: fmt.Errorf("integer %s out of range", s[i:end])}
+			}
+			return fmt.Errorf("invalid target %d of pattern %q: %w", t+1, pattern, err)
+		}
+		i, t = end, t+1
+	}
+
+	if i < len(s) {
+		return &Error{Column: i + 1, Err: fmt.Errorf("unexpected %q at the end of the line", s[i:])}
+	}
+	if t < len(targets) {
+		return fmt.Errorf("pattern %q has %d verbs but got %d targets", pattern, t, len(targets))
+	}
+	return nil
+}
+
+// scan returns the end of the value of a verb that starts at i, rest is the pattern after the verb
+func scan(s string, i int, verb byte, rest string) (int, error) {
+	switch verb {
+	case 'd':
+		end := scanInt(s, i)
+		if end == i {
+			return 0, mismatch(s, i, "integer")
+		}
+		return end, nil
+	case 's':
+		end := len(s)
+		if rest != "" {
+			if rest[0] == '%' && !strings.HasPrefix(rest, "%%") {
+				return 0, fmt.Errorf("invalid pattern: %%s must be followed by a literal")
+			}
+			if k := strings.IndexByte(s[i:], rest[0]); k >= 0 {
+				end = i + k
+			}
+		}
+		if end == i {
+			return 0, mismatch(s, i, "string")
+		}
+		return end, nil
+	case 'c':
+		if i >= len(s) {
+			return 0, mismatch(s, i, "character")
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		return i + size, nil
+	default:
+		return 0, fmt.Errorf("invalid pattern: unknown verb %%%c", verb)
+	}
+}
+
+func store(target any, verb byte, value string) error {
+	switch verb {
+	case 'd':
+		p, ok := target.(*int)
+		if !ok {
+			return fmt.Errorf("%%d needs an *int but got %T", target)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*p = n
+	case 's':
+		p, ok := target.(*string)
+		if !ok {
+			return fmt.Errorf("%%s needs a *string but got %T", target)
+		}
+		*p = value
+	case 'c':
+		p, ok := target.(*rune)
+		if !ok {
+			return fmt.Errorf("%%c needs a *rune but got %T", target)
+		}
+		*p, _ = utf8.DecodeRuneInString(value)
+	}
+	return nil
+}
+
+// literal returns the literal text at the start of a pattern, up to the next verb
+func literal(pattern string) string {
+	var b strings.Builder
+	for j := 0; j < len(pattern); j++ {
+		if pattern[j] == '%' {
+			if j+1 < len(pattern) && pattern[j+1] == '%' {
+				j++
+			} else {
+				break
+			}
+		}
+		b.WriteByte(pattern[j])
+	}
+	return b.String()
+}
+
+// mismatch returns the error of a line that doesn't have what's expected at i
+func mismatch(s string, i int, expected string) error {
+	if i >= len(s) {
+		return &Error{Column: i + 1, Err: fmt.Errorf("expected %s but found the end of the line", expected)}
+	}
+	found := s[i:]
+	if len(found) > 10 {
+		found = found[:10] + "..."
+	}
+	return &Error{Column: i + 1, Err: fmt.Errorf("expected %s but found %q", expected, found)}
+}
@@ -0,0 +1,86 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchShould(t *testing.T) {
+	t.Run("store integers, strings and runes", func(t *testing.T) {
+		var name string
+		var rate int
+		var operator rune
+		err := Match("Valve AA has rate=-13 by *", "Valve %s has rate=%d by %c", &name, &rate, &operator)
+		require.NoError(t, err)
+
+		assert.Equal(t, "AA", name)
+		assert.Equal(t, -13, rate)
+		assert.Equal(t, '*', operator)
+	})
+
+	t.Run("match strings up to the end of the line", func(t *testing.T) {
+		var valves string
+		require.NoError(t, Match("to DD, II, BB", "to %s", &valves))
+
+		assert.Equal(t, "DD, II, BB", valves)
+	})
+
+	t.Run("match literal percent signs", func(t *testing.T) {
+		var n int
+		require.NoError(t, Match("50%", "%d%%", &n))
+
+		assert.Equal(t, 50, n)
+	})
+
+	t.Run("report the column where a literal doesn't match", func(t *testing.T) {
+		var x, y, z int
+		err := Match("1,2;3", "%d,%d,%d", &x, &y, &z)
+
+		assert.EqualError(t, err, `column 4: expected "," but found ";3"`)
+	})
+
+	t.Run("report the column where an integer is missing", func(t *testing.T) {
+		var n int
+		err := Match("move x", "move %d", &n)
+
+		assert.EqualError(t, err, `column 6: expected integer but found "x"`)
+	})
+
+	t.Run("report lines that are too short", func(t *testing.T) {
+		var n int
+		err := Match("move", "move %d", &n)
+
+		assert.EqualError(t, err, "column 5: expected \" \" but found the end of the line")
+	})
+
+	t.Run("report lines that are too long", func(t *testing.T) {
+		var n int
+		err := Match("move 3 crates", "move %d", &n)
+
+		assert.EqualError(t, err, `column 7: unexpected " crates" at the end of the line`)
+	})
+
+	t.Run("report the line when used with Each", func(t *testing.T) {
+		_, err := Each([]string{"1,2", "3;4"}, func(line string) ([2]int, error) {
+			var p [2]int
+			return p, Match(line, "%d,%d", &p[0], &p[1])
+		})
+
+		assert.EqualError(t, err, `line 2, column 2: expected "," but found ";4"`)
+	})
+
+	t.Run("fail with targets of the wrong type", func(t *testing.T) {
+		var s string
+		err := Match("1", "%d", &s)
+
+		assert.ErrorContains(t, err, "%d needs an *int but got *string")
+	})
+
+	t.Run("fail with the wrong number of targets", func(t *testing.T) {
+		var n int
+		assert.Error(t, Match("1 2", "%d %d", &n))
+		assert.Error(t, Match("1", "%d", &n, &n))
+	})
+}
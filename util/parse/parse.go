@@ -0,0 +1,118 @@
+// Package parse holds helpers to parse puzzle inputs whose errors report the line and column that failed.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error is a parse error at a location of the input.
+// Line and Column start at 1, a zero value means the location is unknown.
+type Error struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Line == 0 && e.Column == 0:
+		return e.Err.Error()
+	case e.Line == 0:
+		return fmt.Sprintf("column %d: %v", e.Column, e.Err)
+	case e.Column == 0:
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	default:
+		return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// At returns the error located at the given line, keeping the column if it's already an *Error without line.
+// Errors that are already located at a line are returned as they are.
+func At(line int, err error) error {
+	if err == nil {
+		return nil
+	}
+	var located *Error
+	if errors.As(err, &located) && located.Line > 0 {
+		return err
+	}
+	if e, ok := err.(*Error); ok && e.Line == 0 {
+		return &Error{Line: line, Column: e.Column, Err: e.Err}
+	}
+	return &Error{Line: line, Err: err}
+}
+
+// Lines splits the input in lines, ignoring the trailing newline
+func Lines(input string) []string {
+	return strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+}
+
+// Each parses every line, errors are located at the line that failed
+func Each[T any](lines []string, parse func(line string) (T, error)) ([]T, error) {
+	result := make([]T, 0, len(lines))
+	for i, line := range lines {
+		v, err := parse(line)
+		if err != nil {
+			return nil, At(i+1, err)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// Block is a group of consecutive lines that are not blank
+type Block struct {
+	// Line is the number of the first line of the block in the input, starting at 1
+	Line  int
+	Lines []string
+}
+
+// Blocks splits the input in groups of lines separated by blank lines
+func Blocks(input string) []Block {
+	var blocks []Block
+	var current *Block
+	for i, line := range Lines(input) {
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, Block{Line: i + 1})
+			current = &blocks[len(blocks)-1]
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	return blocks
+}
+
+// EachIn parses every line of a block, errors are located at the line of the input that failed
+func EachIn[T any](block Block, parse func(line string) (T, error)) ([]T, error) {
+	result := make([]T, 0, len(block.Lines))
+	for i, line := range block.Lines {
+		v, err := parse(line)
+		if err != nil {
+			return nil, At(block.Line+i, err)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// EachBlock parses every block, errors that are not located yet are located at the first line of the block
+func EachBlock[T any](blocks []Block, parse func(block Block) (T, error)) ([]T, error) {
+	result := make([]T, 0, len(blocks))
+	for _, block := range blocks {
+		v, err := parse(block)
+		if err != nil {
+			return nil, At(block.Line, err)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
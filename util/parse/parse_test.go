@@ -0,0 +1,103 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorShould(t *testing.T) {
+	t.Run("report its location", func(t *testing.T) {
+		err := errors.New("boom")
+
+		assert.EqualError(t, &Error{Line: 2, Column: 3, Err: err}, "line 2, column 3: boom")
+		assert.EqualError(t, &Error{Line: 2, Err: err}, "line 2: boom")
+		assert.EqualError(t, &Error{Column: 3, Err: err}, "column 3: boom")
+		assert.ErrorIs(t, &Error{Line: 2, Err: err}, err)
+	})
+
+	t.Run("keep the column when located at a line", func(t *testing.T) {
+		err := At(4, &Error{Column: 7, Err: errors.New("boom")})
+
+		assert.EqualError(t, err, "line 4, column 7: boom")
+	})
+
+	t.Run("not relocate errors that already have a line", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", &Error{Line: 2, Err: errors.New("boom")})
+
+		assert.Equal(t, err, At(4, err))
+	})
+
+	t.Run("locate nil errors as nil", func(t *testing.T) {
+		assert.NoError(t, At(4, nil))
+	})
+}
+
+func TestLinesShould(t *testing.T) {
+	t.Run("ignore the trailing newline", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "", "b"}, Lines("a\n\nb\n"))
+	})
+}
+
+func TestEachShould(t *testing.T) {
+	t.Run("parse every line", func(t *testing.T) {
+		ints, err := Each([]string{"1", "2"}, strconv.Atoi)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{1, 2}, ints)
+	})
+
+	t.Run("report the line that failed", func(t *testing.T) {
+		_, err := Each([]string{"1", "x"}, strconv.Atoi)
+
+		var parseErr *Error
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 2, parseErr.Line)
+	})
+}
+
+func TestBlocksShould(t *testing.T) {
+	t.Run("split the input by blank lines", func(t *testing.T) {
+		blocks := Blocks("a\nb\n\n\nc\n  \nd\n")
+
+		assert.Equal(t, []Block{
+			{Line: 1, Lines: []string{"a", "b"}},
+			{Line: 5, Lines: []string{"c"}},
+			{Line: 7, Lines: []string{"d"}},
+		}, blocks)
+	})
+
+	t.Run("report the line of the input that failed", func(t *testing.T) {
+		blocks := Blocks("1\n2\n\n3\nx")
+
+		_, err := EachBlock(blocks, func(block Block) ([]int, error) {
+			ints, err := EachIn(block, strconv.Atoi)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse block: %w", err)
+			}
+			return ints, nil
+		})
+
+		var parseErr *Error
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 5, parseErr.Line)
+		assert.ErrorContains(t, err, "could not parse block: line 5:")
+	})
+
+	t.Run("report the first line of the block for other errors", func(t *testing.T) {
+		blocks := Blocks("1\n2\n\n3\nx")
+
+		_, err := EachBlock(blocks, func(block Block) (int, error) {
+			if len(block.Lines) != 3 {
+				return 0, errors.New("block must have three lines")
+			}
+			return 0, nil
+		})
+
+		assert.EqualError(t, err, "line 1: block must have three lines")
+	})
+}
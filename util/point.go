@@ -0,0 +1,148 @@
+package util
+
+// Point2 represents a point in a 2D space where Y grows downwards, like the lines of the input.
+// In a Grid, X is the column and Y is the row.
+type Point2 struct {
+	X, Y int
+}
+
+// Directions in a 2D space where Y grows downwards
+var (
+	North = Point2{X: 0, Y: -1}
+	South = Point2{X: 0, Y: 1}
+	West  = Point2{X: -1, Y: 0}
+	East  = Point2{X: 1, Y: 0}
+
+	NorthEast = North.Add(East)
+	NorthWest = North.Add(West)
+	SouthEast = South.Add(East)
+	SouthWest = South.Add(West)
+
+	// Compass4 holds the orthogonal directions, clockwise from North
+	Compass4 = []Point2{North, East, South, West}
+	// Compass8 holds the orthogonal and diagonal directions, clockwise from North
+	Compass8 = []Point2{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+)
+
+// Add returns the sum of both points
+func (p Point2) Add(o Point2) Point2 {
+	return Point2{X: p.X + o.X, Y: p.Y + o.Y}
+}
+
+// Sub returns the difference of both points
+func (p Point2) Sub(o Point2) Point2 {
+	return Point2{X: p.X - o.X, Y: p.Y - o.Y}
+}
+
+// Scale returns the point with both coordinates multiplied by k
+func (p Point2) Scale(k int) Point2 {
+	return Point2{X: p.X * k, Y: p.Y * k}
+}
+
+// Manhattan returns the Manhattan distance between both points
+func (p Point2) Manhattan(o Point2) int {
+	return Abs(p.X-o.X) + Abs(p.Y-o.Y)
+}
+
+// Min returns the point with the smallest coordinates of both points
+func (p Point2) Min(o Point2) Point2 {
+	return Point2{X: min(p.X, o.X), Y: min(p.Y, o.Y)}
+}
+
+// Max returns the point with the largest coordinates of both points
+func (p Point2) Max(o Point2) Point2 {
+	return Point2{X: max(p.X, o.X), Y: max(p.Y, o.Y)}
+}
+
+// Neighbours4 returns the orthogonal neighbours of the point, clockwise from North
+func (p Point2) Neighbours4() []Point2 {
+	return p.moves(Compass4)
+}
+
+// Neighbours8 returns the orthogonal and diagonal neighbours of the point, clockwise from North
+func (p Point2) Neighbours8() []Point2 {
+	return p.moves(Compass8)
+}
+
+func (p Point2) moves(directions []Point2) []Point2 {
+	neighbours := make([]Point2, 0, len(directions))
+	for _, direction := range directions {
+		neighbours = append(neighbours, p.Add(direction))
+	}
+	return neighbours
+}
+
+// Point3 represents a point in a 3D space
+type Point3 struct {
+	X, Y, Z int
+}
+
+// Directions3 holds the orthogonal directions in a 3D space
+var Directions3 = []Point3{{X: -1}, {X: 1}, {Y: -1}, {Y: 1}, {Z: -1}, {Z: 1}}
+
+// Add returns the sum of both points
+func (p Point3) Add(o Point3) Point3 {
+	return Point3{X: p.X + o.X, Y: p.Y + o.Y, Z: p.Z + o.Z}
+}
+
+// Sub returns the difference of both points
+func (p Point3) Sub(o Point3) Point3 {
+	return Point3{X: p.X - o.X, Y: p.Y - o.Y, Z: p.Z - o.Z}
+}
+
+// Scale returns the point with all the coordinates multiplied by k
+func (p Point3) Scale(k int) Point3 {
+	return Point3{X: p.X * k, Y: p.Y * k, Z: p.Z * k}
+}
+
+// Manhattan returns the Manhattan distance between both points
+func (p Point3) Manhattan(o Point3) int {
+	return Abs(p.X-o.X) + Abs(p.Y-o.Y) + Abs(p.Z-o.Z)
+}
+
+// Min returns the point with the smallest coordinates of both points
+func (p Point3) Min(o Point3) Point3 {
+	return Point3{X: min(p.X, o.X), Y: min(p.Y, o.Y), Z: min(p.Z, o.Z)}
+}
+
+// Max returns the point with the largest coordinates of both points
+func (p Point3) Max(o Point3) Point3 {
+	return Point3{X: max(p.X, o.X), Y: max(p.Y, o.Y), Z: max(p.Z, o.Z)}
+}
+
+// Neighbours6 returns the points that share a face with the point
+func (p Point3) Neighbours6() []Point3 {
+	neighbours := make([]Point3, 0, len(Directions3))
+	for _, direction := range Directions3 {
+		neighbours = append(neighbours, p.Add(direction))
+	}
+	return neighbours
+}
+
+// Box3 represents the box between two opposite corners, both included
+type Box3 struct {
+	Min, Max Point3
+}
+
+// BoundingBox3 returns the smallest box that contains all the points, which must not be empty
+func BoundingBox3(points []Point3) Box3 {
+	box := Box3{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		box.Min = box.Min.Min(p)
+		box.Max = box.Max.Max(p)
+	}
+	return box
+}
+
+// Contains returns true if the point is inside the box
+func (b Box3) Contains(p Point3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Grow returns the box with n more units in each direction
+func (b Box3) Grow(n int) Box3 {
+	offset := Point3{X: n, Y: n, Z: n}
+	return Box3{Min: b.Min.Sub(offset), Max: b.Max.Add(offset)}
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoint2Should(t *testing.T) {
+	t.Run("do arithmetic", func(t *testing.T) {
+		p := Point2{X: 1, Y: 2}
+
+		assert.Equal(t, Point2{X: 4, Y: 1}, p.Add(Point2{X: 3, Y: -1}))
+		assert.Equal(t, Point2{X: -2, Y: 3}, p.Sub(Point2{X: 3, Y: -1}))
+		assert.Equal(t, Point2{X: 3, Y: 6}, p.Scale(3))
+		assert.Equal(t, Point2{X: 1, Y: -1}, p.Min(Point2{X: 3, Y: -1}))
+		assert.Equal(t, Point2{X: 3, Y: 2}, p.Max(Point2{X: 3, Y: -1}))
+	})
+
+	t.Run("compute Manhattan distance", func(t *testing.T) {
+		assert.Equal(t, 7, Point2{X: 1, Y: 2}.Manhattan(Point2{X: -2, Y: 6}))
+	})
+
+	t.Run("return neighbours with Y growing downwards", func(t *testing.T) {
+		p := Point2{X: 0, Y: 0}
+
+		assert.Equal(t, []Point2{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}, p.Neighbours4())
+		assert.Equal(t, []Point2{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}, p.Neighbours8())
+	})
+}
+
+func TestPoint3Should(t *testing.T) {
+	t.Run("do arithmetic", func(t *testing.T) {
+		p := Point3{X: 1, Y: 2, Z: 3}
+
+		assert.Equal(t, Point3{X: 2, Y: 2, Z: 0}, p.Add(Point3{X: 1, Z: -3}))
+		assert.Equal(t, Point3{X: 0, Y: 2, Z: 6}, p.Sub(Point3{X: 1, Z: -3}))
+		assert.Equal(t, Point3{X: -1, Y: -2, Z: -3}, p.Scale(-1))
+		assert.Equal(t, 6, p.Manhattan(Point3{}))
+	})
+
+	t.Run("return the neighbours that share a face", func(t *testing.T) {
+		neighbours := Point3{X: 1, Y: 1, Z: 1}.Neighbours6()
+
+		assert.ElementsMatch(t, []Point3{{0, 1, 1}, {2, 1, 1}, {1, 0, 1}, {1, 2, 1}, {1, 1, 0}, {1, 1, 2}}, neighbours)
+	})
+}
+
+func TestBox3Should(t *testing.T) {
+	t.Run("contain all the points it bounds", func(t *testing.T) {
+		box := BoundingBox3([]Point3{{1, 5, 2}, {3, 0, 2}, {2, 2, 4}})
+
+		assert.Equal(t, Box3{Min: Point3{1, 0, 2}, Max: Point3{3, 5, 4}}, box)
+		assert.True(t, box.Contains(Point3{2, 3, 3}))
+		assert.False(t, box.Contains(Point3{0, 3, 3}))
+	})
+
+	t.Run("grow in each direction", func(t *testing.T) {
+		box := Box3{Min: Point3{1, 1, 1}, Max: Point3{2, 2, 2}}.Grow(1)
+
+		assert.Equal(t, Box3{Min: Point3{0, 0, 0}, Max: Point3{3, 3, 3}}, box)
+	})
+}
@@ -0,0 +1,132 @@
+package util
+
+// PriorityQueue is a queue where the value with the lowest priority is popped first.
+// Each value is held at most once, pushing a value that is already queued updates its priority.
+type PriorityQueue[T comparable] struct {
+	// items is a binary min-heap ordered by priority
+	items []prioritised[T]
+	// index holds the position of each value in items
+	index map[T]int
+}
+
+type prioritised[T any] struct {
+	value    T
+	priority int
+}
+
+// NewPriorityQueue returns an empty priority queue
+func NewPriorityQueue[T comparable]() *PriorityQueue[T] {
+	return &PriorityQueue[T]{index: make(map[T]int)}
+}
+
+// Len returns the number of values in the queue
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Push adds a value with the given priority, or updates its priority if it's already queued
+func (q *PriorityQueue[T]) Push(v T, priority int) {
+	if i, ok := q.index[v]; ok {
+		old := q.items[i].priority
+		q.items[i].priority = priority
+		if priority < old {
+			q.up(i)
+		} else {
+			q.down(i)
+		}
+		return
+	}
+
+	q.items = append(q.items, prioritised[T]{value: v, priority: priority})
+	q.index[v] = len(q.items) - 1
+	q.up(len(q.items) - 1)
+}
+
+// Pop removes and returns the value with the lowest priority, it panics if the queue is empty
+func (q *PriorityQueue[T]) Pop() (T, int) {
+	if len(q.items) == 0 {
+		panic("pop from empty priority queue")
+	}
+	top := q.items[0]
+	q.removeAt(0)
+	return top.value, top.priority
+}
+
+// Peek returns the value with the lowest priority without removing it, and false if the queue is empty
+func (q *PriorityQueue[T]) Peek() (T, int, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, 0, false
+	}
+	return q.items[0].value, q.items[0].priority, true
+}
+
+// Priority returns the priority of a value and whether it's queued
+func (q *PriorityQueue[T]) Priority(v T) (int, bool) {
+	i, ok := q.index[v]
+	if !ok {
+		return 0, false
+	}
+	return q.items[i].priority, true
+}
+
+// Remove removes a value from the queue, it returns false if it wasn't queued
+func (q *PriorityQueue[T]) Remove(v T) bool {
+	i, ok := q.index[v]
+	if !ok {
+		return false
+	}
+	q.removeAt(i)
+	return true
+}
+
+// removeAt replaces the item at i with the last one and restores the heap order
+func (q *PriorityQueue[T]) removeAt(i int) {
+	last := len(q.items) - 1
+	delete(q.index, q.items[i].value)
+	if i != last {
+		q.items[i] = q.items[last]
+		q.index[q.items[i].value] = i
+	}
+	q.items[last] = prioritised[T]{}
+	q.items = q.items[:last]
+	if i != last {
+		q.down(i)
+		q.up(i)
+	}
+}
+
+// up moves the item at i towards the root while it has a lower priority than its parent
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if q.items[parent].priority <= q.items[i].priority {
+			return
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at i towards the leaves while one of its children has a lower priority
+func (q *PriorityQueue[T]) down(i int) {
+	for {
+		smallest := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(q.items) && q.items[child].priority < q.items[smallest].priority {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		q.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.index[q.items[i].value] = i
+	q.index[q.items[j].value] = j
+}
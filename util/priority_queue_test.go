@@ -0,0 +1,136 @@
+package util
+
+import (
+	"container/heap"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueueShould(t *testing.T) {
+	t.Run("pop values by ascending priority", func(t *testing.T) {
+		q := NewPriorityQueue[string]()
+		q.Push("c", 3)
+		q.Push("a", 1)
+		q.Push("d", 4)
+		q.Push("b", 2)
+
+		var popped []string
+		for q.Len() > 0 {
+			v, _ := q.Pop()
+			popped = append(popped, v)
+		}
+
+		assert.Equal(t, []string{"a", "b", "c", "d"}, popped)
+	})
+
+	t.Run("update the priority of queued values", func(t *testing.T) {
+		q := NewPriorityQueue[string]()
+		q.Push("a", 1)
+		q.Push("b", 5)
+		q.Push("b", 0)
+
+		assert.Equal(t, 2, q.Len())
+		v, priority := q.Pop()
+		assert.Equal(t, "b", v)
+		assert.Equal(t, 0, priority)
+	})
+
+	t.Run("peek without removing", func(t *testing.T) {
+		q := NewPriorityQueue[int]()
+		_, _, ok := q.Peek()
+		assert.False(t, ok)
+
+		q.Push(7, 2)
+		v, priority, ok := q.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, 7, v)
+		assert.Equal(t, 2, priority)
+		assert.Equal(t, 1, q.Len())
+	})
+
+	t.Run("return the priority of queued values", func(t *testing.T) {
+		q := NewPriorityQueue[int]()
+		q.Push(1, 10)
+
+		priority, ok := q.Priority(1)
+		assert.True(t, ok)
+		assert.Equal(t, 10, priority)
+
+		_, ok = q.Priority(2)
+		assert.False(t, ok)
+	})
+
+	t.Run("remove values", func(t *testing.T) {
+		q := NewPriorityQueue[int]()
+		q.Push(1, 1)
+		q.Push(2, 2)
+
+		assert.True(t, q.Remove(1))
+		assert.False(t, q.Remove(1))
+		v, _ := q.Pop()
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("panic when popping from an empty queue", func(t *testing.T) {
+		assert.Panics(t, func() { NewPriorityQueue[int]().Pop() })
+	})
+}
+
+// intHeap is the container/heap baseline for the benchmarks, it can't update priorities
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	priorities := rand.New(rand.NewSource(1)).Perm(10_000)
+
+	b.Run("PriorityQueue", func(b *testing.B) {
+		for range b.N {
+			q := NewPriorityQueue[int]()
+			for v, priority := range priorities {
+				q.Push(v, priority)
+			}
+			for q.Len() > 0 {
+				q.Pop()
+			}
+		}
+	})
+
+	b.Run("PriorityQueue with updates", func(b *testing.B) {
+		for range b.N {
+			q := NewPriorityQueue[int]()
+			for v, priority := range priorities {
+				q.Push(v, priority)
+			}
+			for v := range priorities {
+				q.Push(v, -v)
+			}
+			for q.Len() > 0 {
+				q.Pop()
+			}
+		}
+	})
+
+	b.Run("container/heap", func(b *testing.B) {
+		for range b.N {
+			h := &intHeap{}
+			for _, priority := range priorities {
+				heap.Push(h, priority)
+			}
+			for h.Len() > 0 {
+				heap.Pop(h)
+			}
+		}
+	})
+}
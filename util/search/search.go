@@ -0,0 +1,156 @@
+// Package search finds shortest paths over graphs given by a neighbour function, such as the cells of a grid.
+package search
+
+import (
+	"context"
+	"errors"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+)
+
+// ErrNotFound is returned when no goal state can be reached from the start states
+var ErrNotFound = errors.New("goal not found")
+
+// Edge is a move to a state with the given cost, which must not be negative
+type Edge[S any] struct {
+	To   S
+	Cost int
+}
+
+// Result holds the shortest path found to a goal state
+type Result[S any] struct {
+	// Cost is the sum of the costs of the moves, or the number of moves for BFS
+	Cost int
+	// Path holds the states from a start state to the goal state, both included
+	Path []S
+	// Visited is the number of states that were expanded
+	Visited int
+}
+
+// Goal returns the goal state that was reached
+func (r Result[S]) Goal() S {
+	return r.Path[len(r.Path)-1]
+}
+
+// BFS returns the path with the fewest moves from any of the start states to a goal state.
+// States are compared with ==, so a state must only hold what determines its neighbours.
+func BFS[S comparable](ctx context.Context, neighbours func(S) []S, isGoal func(S) bool, starts ...S) (Result[S], error) {
+	done := ctx.Done()
+	parents := make(map[S]S)
+	depth := make(map[S]int, len(starts))
+
+	queue := util.NewDeque[S]()
+	for _, start := range starts {
+		if _, ok := depth[start]; !ok {
+			depth[start] = 0
+			queue.PushBack(start)
+		}
+	}
+
+	for visited := 1; queue.Len() > 0; visited++ {
+		select {
+		case <-done:
+			return Result[S]{}, ctx.Err()
+		default:
+		}
+
+		state := queue.PopFront()
+		if isGoal(state) {
+			return Result[S]{Cost: depth[state], Path: path(parents, state), Visited: visited}, nil
+		}
+
+		for _, next := range neighbours(state) {
+			if _, ok := depth[next]; ok {
+				continue
+			}
+			depth[next] = depth[state] + 1
+			parents[next] = state
+			queue.PushBack(next)
+		}
+	}
+	return Result[S]{}, ErrNotFound
+}
+
+// Reachable returns all the states that can be reached from the start states, including them
+func Reachable[S comparable](neighbours func(S) []S, starts ...S) util.Set[S] {
+	reached := util.NewSet(starts...)
+	stack := util.NewDeque(starts...)
+	for stack.Len() > 0 {
+		state := stack.PopBack()
+
+		for _, next := range neighbours(state) {
+			if !reached.Contains(next) {
+				reached.Add(next)
+				stack.PushBack(next)
+			}
+		}
+	}
+	return reached
+}
+
+// Dijkstra returns the path with the lowest cost from any of the start states to a goal state
+func Dijkstra[S comparable](ctx context.Context, neighbours func(S) []Edge[S], isGoal func(S) bool, starts ...S) (Result[S], error) {
+	return AStar(ctx, neighbours, isGoal, nil, starts...)
+}
+
+// AStar returns the path with the lowest cost from any of the start states to a goal state.
+// The heuristic estimates the cost from a state to the closest goal. It must be consistent for the path to be the
+// shortest one: it's never higher than the cost of an edge plus the estimate from where the edge leads, and it's 0 at
+// the goals. States are never reopened once expanded, so an admissible but inconsistent heuristic may miss the
+// shortest path. Without heuristic, AStar behaves like Dijkstra.
+func AStar[S comparable](ctx context.Context, neighbours func(S) []Edge[S], isGoal func(S) bool, heuristic func(S) int, starts ...S) (Result[S], error) {
+	if heuristic == nil {
+		heuristic = func(S) int { return 0 }
+	}
+
+	done := ctx.Done()
+	parents := make(map[S]S)
+	costs := make(map[S]int, len(starts))
+	closed := make(map[S]bool)
+
+	frontier := util.NewPriorityQueue[S]()
+	for _, start := range starts {
+		costs[start] = 0
+		frontier.Push(start, heuristic(start))
+	}
+
+	for frontier.Len() > 0 {
+		select {
+		case <-done:
+			return Result[S]{}, ctx.Err()
+		default:
+		}
+
+		state, _ := frontier.Pop()
+		closed[state] = true
+
+		if isGoal(state) {
+			return Result[S]{Cost: costs[state], Path: path(parents, state), Visited: len(closed)}, nil
+		}
+
+		for _, edge := range neighbours(state) {
+			if closed[edge.To] {
+				continue
+			}
+			cost := costs[state] + edge.Cost
+			if known, ok := costs[edge.To]; ok && known <= cost {
+				continue
+			}
+			costs[edge.To] = cost
+			parents[edge.To] = state
+			// Finding a cheaper path to a queued state updates its priority instead of queueing it twice
+			frontier.Push(edge.To, cost+heuristic(edge.To))
+		}
+	}
+	return Result[S]{}, ErrNotFound
+}
+
+// path follows the parents from the goal back to the start state that has no parent
+func path[S comparable](parents map[S]S, goal S) []S {
+	path := []S{goal}
+	for state, ok := parents[goal]; ok; state, ok = parents[state] {
+		path = append(path, state)
+	}
+	util.Reverse(path)
+	return path
+}
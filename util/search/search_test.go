@@ -0,0 +1,165 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OctaviPascual/AdventOfCode2022/util"
+	"github.com/OctaviPascual/AdventOfCode2022/util/parse"
+)
+
+var maze = []string{
+	"S.#.....",
+	".##.###.",
+	"....#..E",
+	".##...#.",
+}
+
+func mazeGrid(t *testing.T) util.Grid[rune] {
+	grid, err := parse.Grid(maze, func(r rune) (rune, error) { return r, nil })
+	require.NoError(t, err)
+	return grid
+}
+
+func mazeNeighbours(grid util.Grid[rune]) func(util.Point2) []util.Point2 {
+	return func(p util.Point2) []util.Point2 {
+		var neighbours []util.Point2
+		for next, cell := range grid.Neighbours4(p) {
+			if cell != '#' {
+				neighbours = append(neighbours, next)
+			}
+		}
+		return neighbours
+	}
+}
+
+func isCell(grid util.Grid[rune], cell rune) func(util.Point2) bool {
+	return func(p util.Point2) bool {
+		return grid.At(p) == cell
+	}
+}
+
+func unitCosts[S any](neighbours func(S) []S) func(S) []Edge[S] {
+	return func(s S) []Edge[S] {
+		var edges []Edge[S]
+		for _, next := range neighbours(s) {
+			edges = append(edges, Edge[S]{To: next, Cost: 1})
+		}
+		return edges
+	}
+}
+
+func assertValidPath(t *testing.T, grid util.Grid[rune], result Result[util.Point2]) {
+	t.Helper()
+	require.Len(t, result.Path, result.Cost+1)
+	for i := 1; i < len(result.Path); i++ {
+		assert.Equal(t, 1, result.Path[i].Manhattan(result.Path[i-1]))
+		assert.NotEqual(t, '#', grid.At(result.Path[i]))
+	}
+}
+
+func TestBFSShould(t *testing.T) {
+	grid := mazeGrid(t)
+
+	t.Run("find the shortest path", func(t *testing.T) {
+		result, err := BFS(context.Background(), mazeNeighbours(grid), isCell(grid, 'E'), util.Point2{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 11, result.Cost)
+		assert.Equal(t, util.Point2{}, result.Path[0])
+		assert.Equal(t, util.Point2{X: 7, Y: 2}, result.Goal())
+		assertValidPath(t, grid, result)
+	})
+
+	t.Run("start from the closest start state", func(t *testing.T) {
+		result, err := BFS(context.Background(), mazeNeighbours(grid), isCell(grid, 'E'), util.Point2{}, util.Point2{X: 5, Y: 0})
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, result.Cost)
+		assert.Equal(t, util.Point2{X: 5, Y: 0}, result.Path[0])
+	})
+
+	t.Run("return the start state if it's a goal", func(t *testing.T) {
+		result, err := BFS(context.Background(), mazeNeighbours(grid), isCell(grid, 'S'), util.Point2{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Cost)
+		assert.Equal(t, []util.Point2{{}}, result.Path)
+	})
+
+	t.Run("fail if the goal can't be reached", func(t *testing.T) {
+		_, err := BFS(context.Background(), mazeNeighbours(grid), isCell(grid, 'X'), util.Point2{})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("stop when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := BFS(ctx, mazeNeighbours(grid), isCell(grid, 'E'), util.Point2{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDijkstraShould(t *testing.T) {
+	t.Run("find the path with the lowest cost", func(t *testing.T) {
+		graph := map[string][]Edge[string]{
+			"A": {{To: "B", Cost: 1}, {To: "C", Cost: 5}},
+			"B": {{To: "C", Cost: 1}, {To: "D", Cost: 7}},
+			"C": {{To: "D", Cost: 2}},
+		}
+		neighbours := func(s string) []Edge[string] { return graph[s] }
+
+		result, err := Dijkstra(context.Background(), neighbours, func(s string) bool { return s == "D" }, "A")
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, result.Cost)
+		assert.Equal(t, []string{"A", "B", "C", "D"}, result.Path)
+	})
+
+	t.Run("fail if the goal can't be reached", func(t *testing.T) {
+		neighbours := func(s string) []Edge[string] { return nil }
+
+		_, err := Dijkstra(context.Background(), neighbours, func(s string) bool { return s == "B" }, "A")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestAStarShould(t *testing.T) {
+	grid := mazeGrid(t)
+	goal := util.Point2{X: 7, Y: 2}
+	heuristic := func(p util.Point2) int { return p.Manhattan(goal) }
+
+	t.Run("find the same cost as BFS visiting fewer states", func(t *testing.T) {
+		neighbours := unitCosts(mazeNeighbours(grid))
+
+		withHeuristic, err := AStar(context.Background(), neighbours, isCell(grid, 'E'), heuristic, util.Point2{})
+		require.NoError(t, err)
+		withoutHeuristic, err := Dijkstra(context.Background(), neighbours, isCell(grid, 'E'), util.Point2{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 11, withHeuristic.Cost)
+		assert.Equal(t, 11, withoutHeuristic.Cost)
+		assertValidPath(t, grid, withHeuristic)
+		assert.Less(t, withHeuristic.Visited, withoutHeuristic.Visited)
+	})
+}
+
+func TestReachableShould(t *testing.T) {
+	t.Run("return all the reachable states", func(t *testing.T) {
+		grid := mazeGrid(t)
+
+		reached := Reachable(mazeNeighbours(grid), util.Point2{})
+
+		open := 0
+		for _, cell := range grid.All() {
+			if cell != '#' {
+				open++
+			}
+		}
+		assert.Len(t, reached, open)
+	})
+}
@@ -0,0 +1,148 @@
+package util
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Set represents a set structure
+// Inspired from https://bitfieldconsulting.com/posts/generic-set
+type Set[E comparable] map[E]struct{}
+
+// NewSet returns a new set
+func NewSet[E comparable](values ...E) Set[E] {
+	s := Set[E]{}
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// CollectSet returns a new set with the values of the iterator
+func CollectSet[E comparable](seq iter.Seq[E]) Set[E] {
+	s := Set[E]{}
+	for v := range seq {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// Add adds an element to the set
+func (s Set[E]) Add(values ...E) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
+
+// Remove removes an element from the set
+func (s Set[E]) Remove(values ...E) {
+	for _, v := range values {
+		delete(s, v)
+	}
+}
+
+// Contains returns true if the value is in the set
+func (s Set[E]) Contains(v E) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Len returns the number of members of the set
+func (s Set[E]) Len() int {
+	return len(s)
+}
+
+// Members returns all the members of the set in a slice, in no particular order
+func (s Set[E]) Members() []E {
+	result := make([]E, 0, len(s))
+	for v := range s {
+		result = append(result, v)
+	}
+	return result
+}
+
+// All returns an iterator over the members of the set, in no particular order
+func (s Set[E]) All() iter.Seq[E] {
+	return maps.Keys(s)
+}
+
+// Clone returns a copy of the set
+func (s Set[E]) Clone() Set[E] {
+	return maps.Clone(s)
+}
+
+// Union returns a new set with the members of both sets
+func (s Set[E]) Union(o Set[E]) Set[E] {
+	result := make(Set[E], max(len(s), len(o)))
+	maps.Copy(result, s)
+	maps.Copy(result, o)
+	return result
+}
+
+// Intersection returns a new set with the members that are in both sets
+func (s Set[E]) Intersection(o Set[E]) Set[E] {
+	smallest, largest := s, o
+	if len(smallest) > len(largest) {
+		smallest, largest = largest, smallest
+	}
+
+	result := Set[E]{}
+	for v := range smallest {
+		if largest.Contains(v) {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set with the members of this set that are not in the other one
+func (s Set[E]) Difference(o Set[E]) Set[E] {
+	result := Set[E]{}
+	for v := range s {
+		if !o.Contains(v) {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set with the members that are in only one of both sets
+func (s Set[E]) SymmetricDifference(o Set[E]) Set[E] {
+	result := s.Difference(o)
+	for v := range o {
+		if !s.Contains(v) {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsSubset returns true if all the members of this set are in the other one
+func (s Set[E]) IsSubset(o Set[E]) bool {
+	if len(s) > len(o) {
+		return false
+	}
+	for v := range s {
+		if !o.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both sets have the same members
+func (s Set[E]) Equal(o Set[E]) bool {
+	return len(s) == len(o) && s.IsSubset(o)
+}
+
+// Sorted returns the members of the set in ascending order
+func Sorted[E cmp.Ordered](s Set[E]) []E {
+	return slices.Sorted(maps.Keys(s))
+}
+
+// SortedAll returns an iterator over the members of the set in ascending order
+func SortedAll[E cmp.Ordered](s Set[E]) iter.Seq[E] {
+	return slices.Values(Sorted(s))
+}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetShould(t *testing.T) {
+	t.Run("work with integer values", func(t *testing.T) {
+		s := NewSet(1, 2)
+		s.Add(4)
+
+		assert.True(t, s.Contains(1))
+		assert.True(t, s.Contains(2))
+		assert.True(t, s.Contains(4))
+		assert.False(t, s.Contains(3))
+		assert.Equal(t, 3, s.Len())
+	})
+
+	t.Run("work with string values", func(t *testing.T) {
+		s := NewSet("a", "b")
+
+		assert.ElementsMatch(t, []string{"a", "b"}, s.Members())
+	})
+
+	t.Run("work with rune values", func(t *testing.T) {
+		s := NewSet('a', 'b')
+		s.Remove('a')
+		s.Remove('c')
+
+		assert.False(t, s.Contains('a'))
+		assert.True(t, s.Contains('b'))
+	})
+
+	t.Run("collect the values of an iterator", func(t *testing.T) {
+		s := CollectSet(slices.Values([]int{3, 1, 3}))
+
+		assert.Equal(t, NewSet(1, 3), s)
+	})
+
+	t.Run("iterate over all the members", func(t *testing.T) {
+		s := NewSet(1, 2, 3)
+
+		assert.ElementsMatch(t, []int{1, 2, 3}, slices.Collect(s.All()))
+	})
+
+	t.Run("iterate over the members in ascending order", func(t *testing.T) {
+		s := NewSet("c", "a", "d", "b")
+
+		assert.Equal(t, []string{"a", "b", "c", "d"}, Sorted(s))
+		assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(SortedAll(s)))
+	})
+
+	t.Run("clone without sharing members", func(t *testing.T) {
+		s := NewSet(1, 2)
+		clone := s.Clone()
+		clone.Add(3)
+
+		assert.Equal(t, NewSet(1, 2), s)
+		assert.Equal(t, NewSet(1, 2, 3), clone)
+	})
+}
+
+func TestSetAlgebraShould(t *testing.T) {
+	a, b := NewSet(1, 2, 3), NewSet(3, 4)
+
+	t.Run("return the union", func(t *testing.T) {
+		assert.Equal(t, NewSet(1, 2, 3, 4), a.Union(b))
+	})
+
+	t.Run("return the intersection", func(t *testing.T) {
+		assert.Equal(t, NewSet(3), a.Intersection(b))
+		assert.Equal(t, NewSet(3), b.Intersection(a))
+	})
+
+	t.Run("return the difference", func(t *testing.T) {
+		assert.Equal(t, NewSet(1, 2), a.Difference(b))
+		assert.Equal(t, NewSet(4), b.Difference(a))
+	})
+
+	t.Run("return the symmetric difference", func(t *testing.T) {
+		assert.Equal(t, NewSet(1, 2, 4), a.SymmetricDifference(b))
+	})
+
+	t.Run("not modify the operands", func(t *testing.T) {
+		assert.Equal(t, NewSet(1, 2, 3), a)
+		assert.Equal(t, NewSet(3, 4), b)
+	})
+
+	t.Run("check subsets", func(t *testing.T) {
+		assert.True(t, NewSet(1, 3).IsSubset(a))
+		assert.True(t, NewSet[int]().IsSubset(a))
+		assert.True(t, a.IsSubset(a))
+		assert.False(t, b.IsSubset(a))
+	})
+
+	t.Run("check equality", func(t *testing.T) {
+		assert.True(t, a.Equal(NewSet(3, 2, 1)))
+		assert.False(t, a.Equal(NewSet(1, 2)))
+		assert.False(t, a.Equal(NewSet(1, 2, 4)))
+	})
+}
@@ -0,0 +1,119 @@
+package util
+
+import (
+	"iter"
+	"strings"
+)
+
+// SparseGrid represents an unbounded 2D grid where only the occupied cells are stored.
+// It keeps the bounding box of the occupied cells up to date as cells are set.
+type SparseGrid[T any] struct {
+	cells map[Point2]T
+	min   Point2
+	max   Point2
+	// stale is true when a cell on the border was deleted, so the bounding box may be too large
+	stale bool
+}
+
+// NewSparseGrid returns an empty sparse grid
+func NewSparseGrid[T any]() *SparseGrid[T] {
+	return &SparseGrid[T]{cells: make(map[Point2]T)}
+}
+
+// Set occupies a cell with the given value
+func (g *SparseGrid[T]) Set(p Point2, v T) {
+	if len(g.cells) == 0 {
+		g.min, g.max, g.stale = p, p, false
+	} else {
+		g.min, g.max = g.min.Min(p), g.max.Max(p)
+	}
+	g.cells[p] = v
+}
+
+// Get returns the value of a cell and whether it's occupied
+func (g *SparseGrid[T]) Get(p Point2) (T, bool) {
+	v, ok := g.cells[p]
+	return v, ok
+}
+
+// Contains returns true if the cell is occupied
+func (g *SparseGrid[T]) Contains(p Point2) bool {
+	_, ok := g.cells[p]
+	return ok
+}
+
+// Delete frees a cell
+func (g *SparseGrid[T]) Delete(p Point2) {
+	if _, ok := g.cells[p]; !ok {
+		return
+	}
+	delete(g.cells, p)
+	if p.X == g.min.X || p.X == g.max.X || p.Y == g.min.Y || p.Y == g.max.Y {
+		g.stale = true
+	}
+}
+
+// Len returns the number of occupied cells
+func (g *SparseGrid[T]) Len() int {
+	return len(g.cells)
+}
+
+// Bounds returns the corners of the smallest rectangle that contains all the occupied cells,
+// and false if there are none
+func (g *SparseGrid[T]) Bounds() (Point2, Point2, bool) {
+	if len(g.cells) == 0 {
+		return Point2{}, Point2{}, false
+	}
+	if g.stale {
+		first := true
+		for p := range g.cells {
+			if first {
+				g.min, g.max, first = p, p, false
+				continue
+			}
+			g.min, g.max = g.min.Min(p), g.max.Max(p)
+		}
+		g.stale = false
+	}
+	return g.min, g.max, true
+}
+
+// Area returns the number of cells, occupied or not, inside the bounds
+func (g *SparseGrid[T]) Area() int {
+	min, max, ok := g.Bounds()
+	if !ok {
+		return 0
+	}
+	return (max.X - min.X + 1) * (max.Y - min.Y + 1)
+}
+
+// All returns an iterator over the occupied cells, in no particular order
+func (g *SparseGrid[T]) All() iter.Seq2[Point2, T] {
+	return func(yield func(Point2, T) bool) {
+		for p, v := range g.cells {
+			if !yield(p, v) {
+				return
+			}
+		}
+	}
+}
+
+// Render returns the cells inside the bounds as lines of runes, from the smallest Y to the largest one.
+// The render function is called for free cells too, with ok set to false.
+func (g *SparseGrid[T]) Render(render func(v T, ok bool) rune) string {
+	min, max, ok := g.Bounds()
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	for y := min.Y; y <= max.Y; y++ {
+		if y > min.Y {
+			b.WriteByte('\n')
+		}
+		for x := min.X; x <= max.X; x++ {
+			b.WriteRune(render(g.Get(Point2{X: x, Y: y})))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func renderSparse(r rune, ok bool) rune {
+	if !ok {
+		return '.'
+	}
+	return r
+}
+
+func TestSparseGridShould(t *testing.T) {
+	t.Run("store occupied cells", func(t *testing.T) {
+		g := NewSparseGrid[rune]()
+		g.Set(Point2{X: 3, Y: -2}, '#')
+
+		r, ok := g.Get(Point2{X: 3, Y: -2})
+		assert.True(t, ok)
+		assert.Equal(t, '#', r)
+		assert.False(t, g.Contains(Point2{X: 0, Y: 0}))
+		assert.Equal(t, 1, g.Len())
+	})
+
+	t.Run("track its bounds as cells are set", func(t *testing.T) {
+		g := NewSparseGrid[rune]()
+		_, _, ok := g.Bounds()
+		assert.False(t, ok)
+
+		g.Set(Point2{X: 3, Y: -2}, '#')
+		g.Set(Point2{X: -1, Y: 4}, '#')
+		g.Set(Point2{X: 0, Y: 0}, '#')
+
+		min, max, ok := g.Bounds()
+		assert.True(t, ok)
+		assert.Equal(t, Point2{X: -1, Y: -2}, min)
+		assert.Equal(t, Point2{X: 3, Y: 4}, max)
+		assert.Equal(t, 35, g.Area())
+	})
+
+	t.Run("shrink its bounds when cells on the border are deleted", func(t *testing.T) {
+		g := NewSparseGrid[rune]()
+		g.Set(Point2{X: 0, Y: 0}, '#')
+		g.Set(Point2{X: 1, Y: 1}, '#')
+		g.Set(Point2{X: 5, Y: 5}, '#')
+
+		g.Delete(Point2{X: 5, Y: 5})
+
+		min, max, _ := g.Bounds()
+		assert.Equal(t, Point2{X: 0, Y: 0}, min)
+		assert.Equal(t, Point2{X: 1, Y: 1}, max)
+
+		g.Delete(Point2{X: 0, Y: 0})
+		g.Delete(Point2{X: 1, Y: 1})
+		_, _, ok := g.Bounds()
+		assert.False(t, ok)
+		assert.Equal(t, 0, g.Area())
+	})
+
+	t.Run("render the occupied region", func(t *testing.T) {
+		g := NewSparseGrid[rune]()
+		g.Set(Point2{X: 10, Y: 5}, '#')
+		g.Set(Point2{X: 12, Y: 6}, 'o')
+
+		assert.Equal(t, "#..\n..o", g.Render(renderSparse))
+		assert.Equal(t, "", NewSparseGrid[rune]().Render(renderSparse))
+	})
+}
@@ -34,45 +34,3 @@ func Abs[T constraints.Integer](x T) T {
 	}
 	return x
 }
-
-// Set represents a set structure
-// Inspired from https://bitfieldconsulting.com/posts/generic-set
-type Set[E comparable] map[E]struct{}
-
-// NewSet returns a new set
-func NewSet[E comparable](values ...E) Set[E] {
-	s := Set[E]{}
-	for _, v := range values {
-		s[v] = struct{}{}
-	}
-	return s
-}
-
-// Add adds an element to the set
-func (s Set[E]) Add(values ...E) {
-	for _, v := range values {
-		s[v] = struct{}{}
-	}
-}
-
-// Remove removes an element from the set
-func (s Set[E]) Remove(values ...E) {
-	for _, v := range values {
-		delete(s, v)
-	}
-}
-
-// Contains returns true if the value is in the set
-func (s Set[E]) Contains(v E) bool {
-	_, ok := s[v]
-	return ok
-}
-
-// Members returns all the members of the set in a slice
-func (s Set[E]) Members() []E {
-	result := make([]E, 0, len(s))
-	for v := range s {
-		result = append(result, v)
-	}
-	return result
-}
@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// DefaultBaseURL is the URL of the Advent of Code website
+const DefaultBaseURL = "https://adventofcode.com"
+
+// Fetcher returns the puzzle input of a day
+type Fetcher interface {
+	Fetch(ctx context.Context, day int) (string, error)
+}
+
+// FileFetcher reads the puzzle input from a local file
+type FileFetcher struct {
+	Path string
+}
+
+// Fetch returns the content of the file, whatever the day is
+func (f FileFetcher) Fetch(_ context.Context, _ int) (string, error) {
+	bytes, err := os.ReadFile(f.Path)
+	if err != nil {
+		return "", fmt.Errorf("could not read file %s: %w", f.Path, err)
+	}
+	return string(bytes), nil
+}
+
+// HTTPFetcher downloads the puzzle input from the Advent of Code website, or any server that serves the same paths
+type HTTPFetcher struct {
+	// BaseURL is the URL that the input paths are relative to, DefaultBaseURL if it's empty
+	BaseURL string
+	// Session is the session cookie of a logged in user, since each user has their own input
+	Session string
+	// Client is the client that sends the requests, http.DefaultClient if it's nil
+	Client *http.Client
+}
+
+// Fetch downloads the input of the given day
+func (f HTTPFetcher) Fetch(ctx context.Context, day int) (string, error) {
+	baseURL := f.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", baseURL, Year, day)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not create request: %w", err)
+	}
+	request.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("could not get %s: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not get %s: %s", url, response.Status)
+	}
+
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not read input from %s: %w", url, err)
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,63 @@
+package workspace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileFetcherShould(t *testing.T) {
+	t.Run("read the input from the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "input.txt")
+		require.NoError(t, os.WriteFile(path, []byte("1000\n"), 0644))
+
+		input, err := FileFetcher{Path: path}.Fetch(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1000\n", input)
+	})
+
+	t.Run("fail if the file doesn't exist", func(t *testing.T) {
+		_, err := FileFetcher{Path: filepath.Join(t.TempDir(), "input.txt")}.Fetch(context.Background(), 1)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestHTTPFetcherShould(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Please log in", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/2022/day/5/input" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("    [D]\n"))
+	}))
+	defer server.Close()
+
+	t.Run("download the input of the day", func(t *testing.T) {
+		input, err := HTTPFetcher{BaseURL: server.URL, Session: "secret", Client: server.Client()}.Fetch(context.Background(), 5)
+		require.NoError(t, err)
+
+		assert.Equal(t, "    [D]\n", input)
+	})
+
+	t.Run("fail without a valid session", func(t *testing.T) {
+		_, err := HTTPFetcher{BaseURL: server.URL, Session: "wrong"}.Fetch(context.Background(), 5)
+		assert.ErrorContains(t, err, "400 Bad Request")
+	})
+
+	t.Run("fail if the day is not found", func(t *testing.T) {
+		_, err := HTTPFetcher{BaseURL: server.URL, Session: "secret"}.Fetch(context.Background(), 6)
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+}
@@ -0,0 +1,155 @@
+// Package workspace creates the package of a new day from templates, with its input and its registration.
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/template"
+)
+
+// Year is the year of the puzzles
+const Year = 2022
+
+// Module is the path of the Go module that the days belong to
+const Module = "github.com/OctaviPascual/AdventOfCode2022"
+
+// DaysFile is the file of the main package that imports all the days so they register themselves
+const DaysFile = "days.go"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+var dayPackage = regexp.MustCompile(`^day\d\d$`)
+
+// Workspace is the repository where the days live
+type Workspace struct {
+	// Root is the directory of the repository
+	Root string
+}
+
+// Day holds what is needed to create a new day
+type Day struct {
+	Number int
+	// Title is the title of the puzzle, it can be filled in later
+	Title string
+}
+
+// Package returns the name of the package of the day, such as day01
+func (d Day) Package() string {
+	return fmt.Sprintf("day%02d", d.Number)
+}
+
+// NewDay creates the package of a day with its input, its solution and test templates,
+// and regenerates DaysFile so that the day registers itself.
+// It fails without changing anything if the package of the day already exists,
+// and removes the package if any of its files can't be written.
+func (w Workspace) NewDay(ctx context.Context, day Day, fetcher Fetcher) (err error) {
+	if day.Number < 1 || day.Number > 25 {
+		return fmt.Errorf("invalid day %d: must be between 1 and 25", day.Number)
+	}
+
+	dir := filepath.Join(w.Root, day.Package())
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("day %d already exists in %s", day.Number, dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not check directory %s: %w", dir, err)
+	}
+
+	// The input is fetched first since it's the step most likely to fail
+	input, err := fetcher.Fetch(ctx, day.Number)
+	if err != nil {
+		return fmt.Errorf("could not fetch input of day %d: %w", day.Number, err)
+	}
+
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return fmt.Errorf("could not create directory %s: %w", dir, err)
+	}
+	// A half-created package would make the next attempt fail because the day already exists
+	defer func() {
+		if err != nil {
+			if removeErr := os.RemoveAll(dir); removeErr != nil {
+				err = errors.Join(err, fmt.Errorf("could not remove directory %s: %w", dir, removeErr))
+			}
+		}
+	}()
+
+	// The input must not be modified by mistake
+	if err := writeFile(filepath.Join(dir, day.Package()+".txt"), []byte(input), 0444); err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		day.Package() + ".go":      "day.go.tmpl",
+		day.Package() + "_test.go": "day_test.go.tmpl",
+	}
+	data := struct {
+		Module string
+		Day
+	}{Module, day}
+	for name, tmpl := range files {
+		source, err := render(tmpl, data)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(dir, name), source, 0644); err != nil {
+			return err
+		}
+	}
+
+	return w.GenerateDays()
+}
+
+// GenerateDays writes DaysFile with an import of each day package in the workspace
+func (w Workspace) GenerateDays() error {
+	entries, err := os.ReadDir(w.Root)
+	if err != nil {
+		return fmt.Errorf("could not read directory %s: %w", w.Root, err)
+	}
+
+	var packages []string
+	for _, entry := range entries {
+		if entry.IsDir() && dayPackage.MatchString(entry.Name()) {
+			packages = append(packages, entry.Name())
+		}
+	}
+
+	source, err := render("days.go.tmpl", struct {
+		Module   string
+		Packages []string
+	}{Module, packages})
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(w.Root, DaysFile), source, 0644)
+}
+
+// render executes a template and formats its output as Go source
+func render(name string, data any) ([]byte, error) {
+	var b bytes.Buffer
+	if err := templates.ExecuteTemplate(&b, name, data); err != nil {
+		return nil, fmt.Errorf("could not execute template %s: %w", name, err)
+	}
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format template %s: %w", name, err)
+	}
+	return source, nil
+}
+
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("could not write file %s: %w", path, err)
+	}
+	return nil
+}
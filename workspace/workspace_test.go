@@ -0,0 +1,116 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	input string
+	err   error
+}
+
+func (f fakeFetcher) Fetch(context.Context, int) (string, error) {
+	return f.input, f.err
+}
+
+func TestWorkspaceShould(t *testing.T) {
+	t.Run("create a new day", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(root, "day01"), 0755))
+		w := Workspace{Root: root}
+
+		err := w.NewDay(context.Background(), Day{Number: 2, Title: "Rock Paper Scissors"}, fakeFetcher{input: "A Y\nB X\n"})
+		require.NoError(t, err)
+
+		input, err := os.ReadFile(filepath.Join(root, "day02", "day02.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "A Y\nB X\n", string(input))
+
+		for _, name := range []string{"day02.go", "day02_test.go"} {
+			file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(root, "day02", name), nil, 0)
+			require.NoError(t, err)
+			assert.Equal(t, "day02", file.Name.Name)
+		}
+
+		source, err := os.ReadFile(filepath.Join(root, "day02", "day02.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(source), `"`+Module+`/registry"`)
+		assert.Contains(t, string(source), `
+		Number:    2,
+		Title:     "Rock Paper Scissors",
+		InputPath: "day02/day02.txt",`)
+
+		days, err := os.ReadFile(filepath.Join(root, DaysFile))
+		require.NoError(t, err)
+		assert.Contains(t, string(days), `
+import (
+	_ "github.com/OctaviPascual/AdventOfCode2022/day01"
+	_ "github.com/OctaviPascual/AdventOfCode2022/day02"
+)
+`)
+	})
+
+	t.Run("fail if the day already exists", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(root, "day03"), 0755))
+
+		err := Workspace{Root: root}.NewDay(context.Background(), Day{Number: 3}, fakeFetcher{})
+		assert.ErrorContains(t, err, "day 3 already exists")
+	})
+
+	t.Run("fail for an invalid day", func(t *testing.T) {
+		err := Workspace{Root: t.TempDir()}.NewDay(context.Background(), Day{Number: 26}, fakeFetcher{})
+		assert.EqualError(t, err, "invalid day 26: must be between 1 and 25")
+	})
+
+	t.Run("not create anything if the input can't be fetched", func(t *testing.T) {
+		root := t.TempDir()
+
+		err := Workspace{Root: root}.NewDay(context.Background(), Day{Number: 4}, fakeFetcher{err: errors.New("offline")})
+		assert.EqualError(t, err, "could not fetch input of day 4: offline")
+
+		entries, err := os.ReadDir(root)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("remove the new day if it can't be registered", func(t *testing.T) {
+		root := t.TempDir()
+		// The days file can't be written over a directory
+		require.NoError(t, os.Mkdir(filepath.Join(root, DaysFile), 0755))
+
+		err := Workspace{Root: root}.NewDay(context.Background(), Day{Number: 5}, fakeFetcher{input: "1\n"})
+		assert.ErrorContains(t, err, "could not write file")
+
+		assert.NoDirExists(t, filepath.Join(root, "day05"))
+	})
+
+	t.Run("generate the days file of the repository", func(t *testing.T) {
+		expected, err := os.ReadFile(filepath.Join("..", DaysFile))
+		require.NoError(t, err)
+
+		root := t.TempDir()
+		entries, err := os.ReadDir("..")
+		require.NoError(t, err)
+		for _, entry := range entries {
+			if entry.IsDir() {
+				require.NoError(t, os.Mkdir(filepath.Join(root, entry.Name()), 0755))
+			}
+		}
+
+		require.NoError(t, Workspace{Root: root}.GenerateDays())
+		actual, err := os.ReadFile(filepath.Join(root, DaysFile))
+		require.NoError(t, err)
+
+		assert.Equal(t, string(expected), string(actual))
+	})
+}